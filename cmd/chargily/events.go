@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+)
+
+// eventCommands are the sub commands of the events command
+var eventCommands = map[string]command{
+	"list":   runEventsList,
+	"show":   runEventsShow,
+	"replay": runEventsReplay,
+}
+
+// runEvents inspects the webhook event journal and replays its events
+func runEvents(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: chargily events list|show|replay [flags]")
+	}
+	cmd, ok := eventCommands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown events command %q, expected list, show or replay", args[0])
+	}
+	return cmd(args[1:])
+}
+
+// journalFlags are the flags selecting the records of the journal
+type journalFlags struct {
+	path    *string
+	since   *string
+	outcome *string
+	kind    *string
+}
+
+func addJournalFlags(fs *flag.FlagSet) journalFlags {
+	return journalFlags{
+		path:    fs.String("journal", os.Getenv("CHARGILY_EVENTS_JOURNAL"), "event journal file, as written by chargily.FileEventJournal"),
+		since:   fs.String("since", "", "keep the events received at or after this date (YYYY-MM-DD, RFC 3339) or for this duration (e.g. 24h)"),
+		outcome: fs.String("outcome", "", "keep the events with this outcome: received, handled, queued, failed or rejected"),
+		kind:    fs.String("type", "", "keep the events of this type (e.g. checkout.paid)"),
+	}
+}
+
+// journal opens the journal of the flags
+func (f journalFlags) journal() (chargily.EventJournal, error) {
+	if *f.path == "" {
+		return nil, errors.New("no event journal: set -journal or CHARGILY_EVENTS_JOURNAL")
+	}
+	if _, err := os.Stat(*f.path); err != nil {
+		return nil, err
+	}
+	return chargily.NewFileEventJournal(*f.path), nil
+}
+
+// filter returns the filter of the flags
+func (f journalFlags) filter() (chargily.EventFilter, error) {
+	filter := chargily.EventFilter{Outcome: chargily.EventOutcome(*f.outcome), Type: *f.kind}
+	if *f.since != "" {
+		since, err := parseSince(*f.since)
+		if err != nil {
+			return filter, err
+		}
+		filter.Since = since
+	}
+	return filter, nil
+}
+
+// selected reports whether the flags select a part of the journal
+func (f journalFlags) selected() bool {
+	return *f.since != "" || *f.outcome != "" || *f.kind != ""
+}
+
+// runEventsList prints the recorded events
+func runEventsList(args []string) error {
+	fs := flag.NewFlagSet("events list", flag.ContinueOnError)
+	flags := addJournalFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	journal, err := flags.journal()
+	if err != nil {
+		return err
+	}
+	filter, err := flags.filter()
+	if err != nil {
+		return err
+	}
+	records, err := journal.List(context.Background(), filter)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RECEIVED\tEVENT\tTYPE\tOUTCOME\tERROR")
+	for _, record := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", record.ReceivedAt.Format(time.RFC3339), record.EventID,
+			record.EventType, record.Outcome, record.Error)
+	}
+	return w.Flush()
+}
+
+// runEventsShow prints the record of an event, with its payload
+func runEventsShow(args []string) error {
+	fs := flag.NewFlagSet("events show", flag.ContinueOnError)
+	flags := addJournalFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: chargily events show [-journal file] <event_id>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected exactly one event ID")
+	}
+
+	journal, err := flags.journal()
+	if err != nil {
+		return err
+	}
+	record, err := journal.Get(context.Background(), fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	// the payload is shown as JSON rather than as a string
+	shown := struct {
+		*chargily.EventRecord
+		Payload json.RawMessage `json:"payload"`
+	}{record, json.RawMessage(record.Payload)}
+	if !json.Valid(shown.Payload) {
+		shown.Payload, _ = json.Marshal(record.Payload)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(shown)
+}
+
+// runEventsReplay sends the recorded events again to a webhook handler
+func runEventsReplay(args []string) error {
+	fs := flag.NewFlagSet("events replay", flag.ContinueOnError)
+	flags := addJournalFlags(fs)
+	target := fs.String("url", "", "URL of the webhook handler")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: chargily events replay -url <url> [flags] [event_id...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *target == "" {
+		fs.Usage()
+		return errors.New("missing -url")
+	}
+	if fs.NArg() == 0 && !flags.selected() {
+		fs.Usage()
+		return errors.New("expected event IDs, or -since, -outcome or -type to select the events")
+	}
+
+	journal, err := flags.journal()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var records []chargily.EventRecord
+	if fs.NArg() > 0 {
+		for _, id := range fs.Args() {
+			record, err := journal.Get(ctx, id)
+			if err != nil {
+				return err
+			}
+			records = append(records, *record)
+		}
+	} else {
+		filter, err := flags.filter()
+		if err != nil {
+			return err
+		}
+		if records, err = journal.List(ctx, filter); err != nil {
+			return err
+		}
+	}
+
+	// the replays are signed with the API key, or the webhook secret, of the environment
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, record := range records {
+		status, err := replayEvent(ctx, client, *target, record)
+		if err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "%s: %v\n", record.EventID, err)
+			continue
+		}
+		fmt.Printf("%s: %s\n", record.EventID, status)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d events failed", failed, len(records))
+	}
+	return nil
+}
+
+// replayEvent posts a recorded payload with its signature and a replay signature, it returns the
+// status of a successful response
+func replayEvent(ctx context.Context, client *chargily.Client, target string, record chargily.EventRecord) (string, error) {
+	payload := []byte(record.Payload)
+	replay, err := client.Webhook.SignReplay(payload, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", target, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("signature", record.Signature)
+	req.Header.Set(chargily.ReplayHeader, replay)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return "", fmt.Errorf("handler answered %s", res.Status)
+	}
+	return res.Status, nil
+}
+
+// parseSince accepts a duration before now (e.g. 24h) or a date, see parseDate
+func parseSince(value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil && !strings.HasPrefix(value, "-") {
+		return time.Now().Add(-d), nil
+	}
+	return parseDate(value)
+}
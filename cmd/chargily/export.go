@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/export"
+)
+
+// exporters maps the resource names accepted by the export command to their exporter
+var exporters = map[string]func(context.Context, *chargily.Client, io.Writer, export.Format, ...export.Option) error{
+	"customers":     export.Customers,
+	"products":      export.Products,
+	"prices":        export.Prices,
+	"checkouts":     export.Checkouts,
+	"payment-links": export.PaymentLinks,
+}
+
+// runExport writes every record of a resource to a file or to the standard output
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "csv", "output format: csv or jsonl")
+	output := fs.String("o", "", "output file (defaults to the standard output)")
+	since := fs.String("since", "", "keep records created at or after this date (YYYY-MM-DD or RFC 3339)")
+	until := fs.String("until", "", "keep records created before this date (YYYY-MM-DD or RFC 3339)")
+	metadata := fs.String("metadata", "", "comma separated metadata keys to add as CSV columns")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: chargily export [flags] customers|products|prices|checkouts|payment-links")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected exactly one resource")
+	}
+	exporter, ok := exporters[fs.Arg(0)]
+	if !ok {
+		return fmt.Errorf("unknown resource %q", fs.Arg(0))
+	}
+
+	var opts []export.Option
+	if *since != "" {
+		t, err := parseDate(*since)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, export.CreatedAfter(t))
+	}
+	if *until != "" {
+		t, err := parseDate(*until)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, export.CreatedBefore(t))
+	}
+	if *metadata != "" {
+		opts = append(opts, export.MetadataKeys(strings.Split(*metadata, ",")...))
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return exporter(ctx, client, w, export.Format(*format), opts...)
+}
+
+// parseDate accepts either a date (YYYY-MM-DD, UTC) or a RFC 3339 timestamp
+func parseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or RFC 3339", value)
+	}
+	return t, nil
+}
@@ -0,0 +1,62 @@
+// Command chargily is a small command line tool built on top of the SDK.
+//
+// The client is configured by the CHARGILY_* environment variables, see chargily.LoadEnvConfig:
+// the API key is read from CHARGILY_API_KEY and the mode ("test" or "prod", defaults to "test")
+// from CHARGILY_MODE.
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+)
+
+// command is a sub command of the tool, it receives the remaining arguments
+type command func(args []string) error
+
+var commands = map[string]command{
+	"events": runEvents,
+	"export": runExport,
+	"report": runReport,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "chargily %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+// usage prints the available commands
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: chargily <command> [flags]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n", name)
+	}
+}
+
+// newClient creates the API client from the environment
+func newClient() (*chargily.Client, error) {
+	return chargily.NewClientFromEnv()
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/Chargily/chargily-pay-go/pkg/analytics"
+)
+
+// runReport prints the revenue and fee report of the checkout history
+func runReport(args []string) error {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	format := fs.String("format", "csv", "output format: csv or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != "csv" && *format != "json" {
+		return fmt.Errorf("unsupported format %q", *format)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := analytics.Generate(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		return report.WriteJSON(os.Stdout)
+	}
+	return report.WriteCSV(os.Stdout)
+}
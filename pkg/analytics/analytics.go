@@ -0,0 +1,153 @@
+package analytics
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// StatusPaid is the checkout status counted as a successful payment.
+const StatusPaid = "paid"
+
+// Summary holds the revenue and fee figures of a set of checkouts, all amounts are in cents.
+type Summary struct {
+	Checkouts      int     `json:"checkouts"`        // Number of checkouts created.
+	Paid           int     `json:"paid"`             // Number of paid checkouts.
+	GrossVolume    int64   `json:"gross_volume"`     // Sum of the amounts of paid checkouts.
+	Fees           int64   `json:"fees"`             // Total fees of paid checkouts.
+	FeesOnMerchant int64   `json:"fees_on_merchant"` // Fees paid by the merchant.
+	FeesOnCustomer int64   `json:"fees_on_customer"` // Fees passed to the customers.
+	NetRevenue     int64   `json:"net_revenue"`      // Gross volume minus the fees paid by the merchant.
+	Discounts      int64   `json:"discounts"`        // Total discounts granted on paid checkouts.
+	ConversionRate float64 `json:"conversion_rate"`  // Paid checkouts over created checkouts (0 to 1).
+}
+
+// Breakdown is the summary of the checkouts sharing the same key (a day, a currency ...).
+type Breakdown struct {
+	Key string `json:"key"`
+	Summary
+}
+
+// Report is the result of the analysis of a checkout history.
+// The totals mix every currency, use ByCurrency when more than one currency is involved.
+type Report struct {
+	Summary
+	ByDay           []Breakdown `json:"by_day"`            // Keyed by the creation date (YYYY-MM-DD, UTC).
+	ByPaymentMethod []Breakdown `json:"by_payment_method"` // Keyed by the payment method ("unknown" if not set).
+	ByCurrency      []Breakdown `json:"by_currency"`       // Keyed by the currency code.
+	ByPaymentLink   []Breakdown `json:"by_payment_link"`   // Keyed by the payment link ID ("none" if not set).
+}
+
+// Generate fetches every checkout of the account page by page and computes its report.
+func Generate(ctx context.Context, client *chargily.Client) (*Report, error) {
+	// the page requests are canceled with the context
+	client = client.WithContext(ctx)
+	first, err := client.Checkouts.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var checkouts []models.Checkout
+	err = chargily.ForEach(client, first, func(checkout models.Checkout) error {
+		// stop walking through the pages once the context is done
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		checkouts = append(checkouts, checkout)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return Compute(checkouts), nil
+}
+
+// Compute builds the report of the given checkouts.
+func Compute(checkouts []models.Checkout) *Report {
+	var report Report
+	byDay := map[string]*Summary{}
+	byMethod := map[string]*Summary{}
+	byCurrency := map[string]*Summary{}
+	byLink := map[string]*Summary{}
+
+	for _, checkout := range checkouts {
+		report.add(checkout)
+		group(byDay, day(checkout.CreatedAt)).add(checkout)
+		group(byMethod, valueOr(checkout.PaymentMethod, "unknown")).add(checkout)
+		group(byCurrency, checkout.Currency).add(checkout)
+		group(byLink, valueOr(checkout.PaymentLinkID, "none")).add(checkout)
+	}
+
+	report.finish()
+	report.ByDay = breakdowns(byDay)
+	report.ByPaymentMethod = breakdowns(byMethod)
+	report.ByCurrency = breakdowns(byCurrency)
+	report.ByPaymentLink = breakdowns(byLink)
+	return &report
+}
+
+// add accounts the checkout in the summary
+func (s *Summary) add(checkout models.Checkout) {
+	s.Checkouts++
+	if checkout.Status != StatusPaid {
+		return
+	}
+
+	s.Paid++
+	s.GrossVolume += checkout.Amount
+	s.Fees += checkout.Fees
+	s.FeesOnMerchant += checkout.FeesOnMerchant
+	s.FeesOnCustomer += checkout.FeesOnCustomer
+	s.NetRevenue += checkout.Amount - checkout.FeesOnMerchant
+	if checkout.AmountWithoutDiscount > checkout.Amount {
+		s.Discounts += checkout.AmountWithoutDiscount - checkout.Amount
+	}
+}
+
+// finish computes the derived figures once every checkout was added
+func (s *Summary) finish() {
+	if s.Checkouts > 0 {
+		s.ConversionRate = float64(s.Paid) / float64(s.Checkouts)
+	}
+}
+
+// group returns the summary stored under the key, creating it if needed
+func group(groups map[string]*Summary, key string) *Summary {
+	s, ok := groups[key]
+	if !ok {
+		s = &Summary{}
+		groups[key] = s
+	}
+	return s
+}
+
+// breakdowns converts the groups to a slice sorted by key
+func breakdowns(groups map[string]*Summary) []Breakdown {
+	result := make([]Breakdown, 0, len(groups))
+	for key, s := range groups {
+		s.finish()
+		result = append(result, Breakdown{Key: key, Summary: *s})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// valueOr dereferences a nullable field, falling back to def when it is null or empty
+func valueOr(value *string, def string) string {
+	if value == nil || *value == "" {
+		return def
+	}
+	return *value
+}
+
+// day returns the UTC date of a creation time, "unknown" when the API didn't send it
+func day(createdAt models.Timestamp) string {
+	if createdAt.IsZero() {
+		return "unknown"
+	}
+	return createdAt.UTC().Format(time.DateOnly)
+}
@@ -0,0 +1,70 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// csvHeader is the stable column order of the CSV output
+var csvHeader = []string{
+	"group", "key", "checkouts", "paid", "gross_volume", "fees", "fees_on_merchant",
+	"fees_on_customer", "net_revenue", "discounts", "conversion_rate",
+}
+
+// WriteJSON writes the report as an indented JSON document.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes the report as CSV, one row for the totals followed by one row per breakdown entry.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	if err := cw.Write(csvRow("total", "", r.Summary)); err != nil {
+		return err
+	}
+
+	groups := []struct {
+		name    string
+		entries []Breakdown
+	}{
+		{"day", r.ByDay},
+		{"payment_method", r.ByPaymentMethod},
+		{"currency", r.ByCurrency},
+		{"payment_link", r.ByPaymentLink},
+	}
+	for _, g := range groups {
+		for _, b := range g.entries {
+			if err := cw.Write(csvRow(g.name, b.Key, b.Summary)); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvRow formats a summary following the csvHeader columns
+func csvRow(group, key string, s Summary) []string {
+	return []string{
+		group,
+		key,
+		strconv.Itoa(s.Checkouts),
+		strconv.Itoa(s.Paid),
+		strconv.FormatInt(s.GrossVolume, 10),
+		strconv.FormatInt(s.Fees, 10),
+		strconv.FormatInt(s.FeesOnMerchant, 10),
+		strconv.FormatInt(s.FeesOnCustomer, 10),
+		strconv.FormatInt(s.NetRevenue, 10),
+		strconv.FormatInt(s.Discounts, 10),
+		strconv.FormatFloat(s.ConversionRate, 'f', 4, 64),
+	}
+}
@@ -1,8 +1,6 @@
 package chargily
 
 import (
-	"strings"
-
 	"github.com/Chargily/chargily-pay-go/pkg/models"
 )
 
@@ -13,14 +11,6 @@ type Balance struct {
 
 //retrieve the balance example
 func (b * Balance) Get() (*models.Balance, error) {
-
-    var balance models.Balance
-    //send the request 
-    err := b.client.rs.SendRequest("GET",  strings.Join([]string{b.client.endpoint, "balance"}, ""), nil, &balance)
-
-    if err != nil {
-        return nil, err
-    }
-	// Return the parsed balance object
-	return &balance, nil
-}
\ No newline at end of file
+    // Return the parsed balance object
+    return call[models.Balance](b.client, "GET", b.client.url("balance"), nil, operation("balance", "get", ""))
+}
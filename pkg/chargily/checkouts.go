@@ -1,90 +1,49 @@
 package chargily
 
 import (
-	"strings"
-
 	"github.com/Chargily/chargily-pay-go/pkg/models"
 )
 
 //============ CHECKOUT FUNCTIONALITIES =================//
 
 type Checkouts struct {
-    client * Client
+    resource[models.CheckoutParams, models.CheckoutParams, models.Checkout]
 }
 
 
 
 
-//create a checkout
-func (c * Checkouts) Create(checkout *models.CheckoutParams) (*models.Checkout, error) {
-    var checkoutResp models.Checkout
-    //send the request 
-    err := c.client.rs.SendRequest("POST",  strings.Join([]string{c.client.endpoint, "checkouts"}, ""), checkout, &checkoutResp)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed checkout object
-    return &checkoutResp, nil
+//create a checkout, pass WithIdempotencyKey to safely retry the call
+func (c * Checkouts) Create(checkout *models.CheckoutParams, opts ...RequestOption) (*models.Checkout, error) {
+    return c.create(checkout, opts...)
 }
 
 
 // retrieve a checkout
 func (c * Checkouts) Get(checkoutId string) (*models.Checkout, error) {
-
-    var checkout models.Checkout
-    //send the request 
-    err := c.client.rs.SendRequest("GET",  strings.Join([]string{c.client.endpoint, "checkouts/", checkoutId }, ""), nil, &checkout)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed checkout object
-    return &checkout, nil
+    return c.get(checkoutId)
 }
 
 
-// retrieve all checkouts
-func (c * Checkouts) GetAll() (*models.RetrieveAll[models.Checkout], error) {
-
-    var checkouts models.RetrieveAll[models.Checkout]
-    //send the request 
-    err := c.client.rs.SendRequest("GET",  strings.Join([]string{c.client.endpoint, "checkouts"}, ""), nil, &checkouts)
+// retrieve all checkouts, optionally filtered by status, customer, payment link or creation time
+func (c * Checkouts) GetAll(params ...*models.ListCheckoutsParams) (*models.RetrieveAll[models.Checkout], error) {
+    return c.list(first(params))
+}
 
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed checkout object
-    return &checkouts, nil
+// walk through the pages of all the checkouts, optionally filtered by the params
+func (c * Checkouts) List(params ...*models.ListCheckoutsParams) *Pager[models.Checkout] {
+    return c.pager(first(params))
 }
 
 
 
 // retrieve a checkout's items
 func (c * Checkouts) GetItems(checkoutId string) (*models.RetrieveAll[models.CheckoutItems], error) {
-
-    var items models.RetrieveAll[models.CheckoutItems]
-    //send the request 
-    err := c.client.rs.SendRequest("GET",  strings.Join([]string{c.client.endpoint, "checkouts/", checkoutId, "/items"}, ""), nil, &items)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed checkout items object
-    return &items, nil
+    return subList[models.CheckoutItems](c.resource, checkoutId, "items")
 }
 
 
 // expires a checkout 
 func (c * Checkouts) Expire(checkoutId string) (*models.Checkout ,error) {
-
-    //send the request 
-    var checkout models.Checkout
-    err := c.client.rs.SendRequest("POST",  strings.Join([]string{c.client.endpoint, "checkouts/", checkoutId, "/expire"}, ""), nil, &checkout)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return nil if the request was successful
-    return &checkout ,nil
+    return c.action(checkoutId, "expire")
 }
@@ -1,12 +1,19 @@
 package chargily
 
 import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
 	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
 )
 
 // Client is the structure that holds the API key , the endpoint for the Chargily API and the development mode.
 type Client struct {
-    apiKey   		string
+    credentials     CredentialsProvider // gives the API key of every request
+    webhookSecret   string // checks the webhook signatures instead of the API key, when set
     endpoint 		string
     rs              utils.RequestSenderI // rs: stands for RequestSender and used to send custom http requests
 	mode            Mode
@@ -17,12 +24,25 @@ type Client struct {
     PaymentLinks    *PaymentLinks
     Checkouts       *Checkouts
     Webhook        *Webhook
+    senderOpts      []utils.SenderOption // options of the request sender, collected from the client options
+    webhookTracer   WebhookTracer
+    logger          *slog.Logger
+    redactedKeys    []string // metadata keys hidden from the logs
+    redactor        *utils.Redactor
+    strict          bool // report the drifts between the responses and the models
+    driftHandler    DriftHandler
+    livemodeAction  LivemodeAction // what to do with the responses and the events of the other mode
+    livemodeHandler LivemodeHandler
+    replayTolerance time.Duration // age of the oldest webhook event accepted, 0 accepts any age
+    eventStore      EventStore // records the received webhook events, to refuse them when replayed
+    journal         EventJournal // records the webhook requests and the outcome of their events
 }
 
 
 
 // NewClient initializes and returns a new Client with the given API key and endpoint.
-func NewClient(apiKey , mode string) (*Client, error) {
+// Optional features such as rate limiting are enabled through the options.
+func NewClient(apiKey , mode string, opts ...ClientOption) (*Client, error) {
 
     //Set the API base URL based on the mode provided
     var api_baseUrl string
@@ -38,25 +58,74 @@ func NewClient(apiKey , mode string) (*Client, error) {
     }
 
 
-    //new request sender 
-    requestSender := utils.NewRequestSender(apiKey)
-    
     //return the client with it's configurations
     client :=  &Client{
-        apiKey:   	apiKey,
+        credentials: StaticCredentials(apiKey),
         endpoint: 	api_baseUrl,
-        rs:         requestSender,
 		mode:       Mode(mode), //test: for testing/development stage , prod: for production applications
     }
 
-    client.Balance =     &Balance{client: client}
-    client.Customers =   &Customers{client: client}
-    client.Prices =      &Prices{client: client}
-    client.Products =    &Products{client: client}
-    client.PaymentLinks = &PaymentLinks{client: client}
-    client.Checkouts =   &Checkouts{client: client}
-    client.Webhook = &Webhook{client: client}
+    //apply the optional features
+    for _, opt := range opts {
+        opt(client)
+    }
+
+    //get the API key of every request from the credentials, so it can be rotated
+    client.senderOpts = append(client.senderOpts, utils.WithCredentials(client.credentials))
+
+    //log the requests, without the secrets and the personal data
+    var secrets []string
+    for _, secret := range []string{apiKey, client.webhookSecret} {
+        if secret != "" {
+            secrets = append(secrets, secret)
+        }
+    }
+    client.redactor = utils.NewRedactor(secrets, client.redactedKeys)
+    if client.logger != nil {
+        client.senderOpts = append(client.senderOpts, utils.WithMiddleware(utils.LoggingMiddleware(client.logger, client.redactor)))
+    }
+
+    //report the drifts of the responses, as warnings in the logs unless a handler is given
+    if client.strict {
+        handler := client.driftHandler
+        if handler == nil {
+            handler = logDrift(client.logger)
+        }
+        client.senderOpts = append(client.senderOpts, utils.WithDriftHandler(handler))
+    }
+
+    //check that the responses belong to the mode of the client, unless the mismatches would go unnoticed
+    if client.checksLivemode() {
+        client.senderOpts = append(client.senderOpts, utils.WithLivemodeCheck(client.live(), client.checkLivemode))
+    }
+
+    //new request sender 
+    client.rs = utils.NewRequestSender(apiKey, client.senderOpts...)
+
+    client.initServices()
 
     return client, nil
 }
 
+
+// initServices binds the services to the client
+func (client *Client) initServices() {
+    client.Balance =     &Balance{client: client}
+    client.Customers =   &Customers{newResource[models.CreateCustomerParams, models.CreateCustomerParams, models.Customer](client, "customers")}
+    client.Prices =      &Prices{newResource[models.ProductPriceParams, models.UpdatePriceMetaDataParams, models.ProductPrice](client, "prices")}
+    client.Products =    &Products{newResource[models.CreateProductParams, models.CreateProductParams, models.Product](client, "products")}
+    client.PaymentLinks = &PaymentLinks{newResource[models.CreatePaymentLinkParams, models.CreatePaymentLinkParams, models.PaymentLink](client, "payment-links")}
+    client.Checkouts =   &Checkouts{newResource[models.CheckoutParams, models.CheckoutParams, models.Checkout](client, "checkouts")}
+    client.Webhook = &Webhook{client: client}
+}
+
+
+// Redact hides the API key, emails, phone numbers and the metadata keys set with WithRedactedMetadataKeys
+// from a text, e.g. the message of an error returned by the client before logging it.
+func (c *Client) Redact(text string) string {
+    // the current API key, when the credentials rotate it
+    if key, err := c.credentials.APIKey(context.Background()); err == nil && key != "" {
+        text = strings.ReplaceAll(text, key, utils.Redacted)
+    }
+    return c.redactor.Redact(text)
+}
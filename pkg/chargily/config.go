@@ -0,0 +1,304 @@
+package chargily
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"gopkg.in/yaml.v3"
+)
+
+//============ CONFIGURATION =================//
+// A client can be configured from the environment or from a file, with the same keys:
+//
+//	key             environment variable         value
+//	api_key         CHARGILY_API_KEY             the API key
+//	api_key_file    CHARGILY_API_KEY_FILE        a file holding the API key, read again when it changes
+//	mode            CHARGILY_MODE                "test" (default) or "prod"
+//	base_url        CHARGILY_BASE_URL            another API endpoint than the one of the mode
+//	timeout         CHARGILY_TIMEOUT             time given to a call, e.g. "30s" or 30 (seconds)
+//	max_retries     CHARGILY_MAX_RETRIES         times a throttled request is sent again
+//	rate_limit      CHARGILY_RATE_LIMIT          requests per second
+//	rate_burst      CHARGILY_RATE_BURST          requests sent at once, 1 by default
+//	webhook_secret  CHARGILY_WEBHOOK_SECRET      checks the webhook signatures instead of the API key
+
+// ErrInvalidConfig is returned when a configuration value is missing or invalid, the error tells which one
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// envPrefix is the prefix of the environment variables, the rest of their name is the upper case key
+const envPrefix = "CHARGILY_"
+
+// configKeys are the keys of a configuration, in the order they are checked
+var configKeys = []string{
+	"api_key", "api_key_file", "mode", "base_url", "timeout",
+	"max_retries", "rate_limit", "rate_burst", "webhook_secret",
+}
+
+// Config is the configuration of a client, see LoadEnvConfig and LoadConfig.
+type Config struct {
+	APIKey        string
+	APIKeyFile    string
+	Mode          Mode
+	BaseURL       string
+	Timeout       time.Duration
+	MaxRetries    *int // nil keeps the default
+	RateLimit     float64
+	RateBurst     int
+	WebhookSecret string
+}
+
+// NewClientFromEnv creates a client configured by the CHARGILY_* environment variables, the
+// options are applied after the configuration.
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	config, err := LoadEnvConfig()
+	if err != nil {
+		return nil, err
+	}
+	return config.NewClient(opts...)
+}
+
+// NewClientFromConfig creates a client configured by a file, the options are applied after the
+// configuration.
+func NewClientFromConfig(path string, opts ...ClientOption) (*Client, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	return config.NewClient(opts...)
+}
+
+// LoadEnvConfig reads the configuration from the CHARGILY_* environment variables.
+func LoadEnvConfig() (*Config, error) {
+	values := make(map[string]string)
+	for _, key := range configKeys {
+		if value, ok := os.LookupEnv(envName(key)); ok {
+			values[key] = value
+		}
+	}
+	return parseConfig(values, envName)
+}
+
+// LoadConfig reads the configuration from a file: JSON if its extension is .json, YAML if it is
+// .yaml or .yml, and lines of key=value otherwise (e.g. a .env file). The keys may also be given
+// as their environment variable.
+func LoadConfig(path string) (*Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		values, err = decodeConfig(raw, json.Unmarshal)
+	case ".yaml", ".yml":
+		values, err = decodeConfig(raw, yaml.Unmarshal)
+	default:
+		values, err = parseKeyValues(raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+
+	return parseConfig(values, func(key string) string {
+		return path + ": " + key
+	})
+}
+
+// NewClient creates the client with the configuration, the options are applied after it.
+func (c *Config) NewClient(opts ...ClientOption) (*Client, error) {
+	return NewClient(c.APIKey, string(c.Mode), append(c.Options(), opts...)...)
+}
+
+// Options returns the client options of the configuration, the API key and the mode excepted.
+func (c *Config) Options() []ClientOption {
+	var opts []ClientOption
+	if c.APIKeyFile != "" {
+		opts = append(opts, WithCredentials(NewFileCredentials(c.APIKeyFile)))
+	}
+	if c.BaseURL != "" {
+		opts = append(opts, WithBaseURL(c.BaseURL))
+	}
+	if c.Timeout > 0 {
+		opts = append(opts, WithTimeout(c.Timeout))
+	}
+	if c.MaxRetries != nil {
+		opts = append(opts, WithMaxRetries(*c.MaxRetries))
+	}
+	if c.RateLimit > 0 {
+		burst := c.RateBurst
+		if burst == 0 {
+			burst = 1
+		}
+		opts = append(opts, WithRateLimit(c.RateLimit, burst))
+	}
+	if c.WebhookSecret != "" {
+		opts = append(opts, WithWebhookSecret(c.WebhookSecret))
+	}
+	return opts
+}
+
+// envName returns the environment variable of a key
+func envName(key string) string {
+	return envPrefix + strings.ToUpper(key)
+}
+
+// configKey returns the key of a name given either as a key or as its environment variable
+func configKey(name string) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	key = strings.TrimPrefix(key, strings.ToLower(envPrefix))
+	for _, known := range configKeys {
+		if key == known {
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("unknown key %q", name)
+}
+
+// decodeConfig decodes a JSON or YAML object of scalar values
+func decodeConfig(raw []byte, unmarshal func([]byte, any) error) (map[string]string, error) {
+	var object map[string]any
+	if err := unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(object))
+	for name, value := range object {
+		key, err := configKey(name)
+		if err != nil {
+			return nil, err
+		}
+		switch v := value.(type) {
+		case nil:
+		case string:
+			values[key] = v
+		case float64:
+			values[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case int:
+			values[key] = strconv.Itoa(v)
+		default:
+			return nil, fmt.Errorf("%s: expected a string or a number, got %T", name, value)
+		}
+	}
+	return values, nil
+}
+
+// parseKeyValues parses lines of key=value, the empty lines and the lines starting with # are
+// skipped, an "export " prefix and quotes around the values are allowed
+func parseKeyValues(raw []byte) (map[string]string, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		name, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key=value", line)
+		}
+		key, err := configKey(name)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
+
+// parseConfig checks the values of a configuration, name tells where a key comes from in the errors
+func parseConfig(values map[string]string, name func(key string) string) (*Config, error) {
+	invalid := func(key, format string, args ...any) error {
+		return fmt.Errorf("%w: %s %s", ErrInvalidConfig, name(key), fmt.Sprintf(format, args...))
+	}
+
+	config := &Config{
+		APIKey:        values["api_key"],
+		APIKeyFile:    values["api_key_file"],
+		Mode:          Mode(values["mode"]),
+		WebhookSecret: values["webhook_secret"],
+	}
+
+	switch {
+	case config.APIKey == "" && config.APIKeyFile == "":
+		return nil, fmt.Errorf("%w: %s is not set", ErrMissingAPIKey, name("api_key"))
+	case config.APIKey != "" && config.APIKeyFile != "":
+		return nil, invalid("api_key_file", "can't be set with %s", name("api_key"))
+	}
+
+	switch config.Mode {
+	case "":
+		config.Mode = Test
+	case Test, Prod:
+	default:
+		return nil, fmt.Errorf("%w (%w): %s must be %q or %q, got %q",
+			ErrInvalidConfig, utils.ErrInvalidMode, name("mode"), Test, Prod, config.Mode)
+	}
+
+	if value := values["base_url"]; value != "" {
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, invalid("base_url", "must be an http or https URL, got %q", value)
+		}
+		config.BaseURL = value
+	}
+
+	if value := values["timeout"]; value != "" {
+		timeout, err := time.ParseDuration(value)
+		if seconds, errSeconds := strconv.ParseFloat(value, 64); errSeconds == nil {
+			// "Inf", "NaN" and the durations past the range of time.Duration are refused
+			timeout, err = time.Duration(seconds*float64(time.Second)), nil
+			if math.IsNaN(seconds) || math.IsInf(seconds, 0) || seconds*float64(time.Second) >= math.MaxInt64 {
+				timeout = 0
+			}
+		}
+		if err != nil || timeout <= 0 {
+			return nil, invalid("timeout", "must be a positive duration such as \"30s\", got %q", value)
+		}
+		config.Timeout = timeout
+	}
+
+	if value := values["max_retries"]; value != "" {
+		retries, err := strconv.Atoi(value)
+		if err != nil || retries < 0 {
+			return nil, invalid("max_retries", "must be a positive integer or 0, got %q", value)
+		}
+		config.MaxRetries = &retries
+	}
+
+	if value := values["rate_limit"]; value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || !(rate > 0) || math.IsInf(rate, 0) {
+			return nil, invalid("rate_limit", "must be a positive number of requests per second, got %q", value)
+		}
+		config.RateLimit = rate
+	}
+
+	if value := values["rate_burst"]; value != "" {
+		burst, err := strconv.Atoi(value)
+		if err != nil || burst <= 0 {
+			return nil, invalid("rate_burst", "must be a positive integer, got %q", value)
+		}
+		if config.RateLimit == 0 {
+			return nil, invalid("rate_burst", "needs %s", name("rate_limit"))
+		}
+		config.RateBurst = burst
+	}
+
+	return config, nil
+}
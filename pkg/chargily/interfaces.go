@@ -0,0 +1,122 @@
+package chargily
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+//============ RESOURCE INTERFACES =================//
+// Depend on these interfaces rather than on the concrete services to substitute fakes in tests,
+// ready to use mocks are available in the chargilymock package.
+
+// BalanceAPI is implemented by *Balance.
+type BalanceAPI interface {
+	Get() (*models.Balance, error)
+}
+
+// CustomersAPI is implemented by *Customers.
+type CustomersAPI interface {
+	Create(customer *models.CreateCustomerParams, opts ...RequestOption) (*models.Customer, error)
+	Update(customerID string, customer *models.CreateCustomerParams) (*models.Customer, error)
+	Get(customerID string) (*models.Customer, error)
+	Delete(customerID string) error
+	GetAll(params ...*models.ListCustomersParams) (*models.RetrieveAll[models.Customer], error)
+	List(params ...*models.ListCustomersParams) *Pager[models.Customer]
+}
+
+// ProductsAPI is implemented by *Products.
+type ProductsAPI interface {
+	Create(product *models.CreateProductParams, opts ...RequestOption) (*models.Product, error)
+	Update(productId string, product *models.CreateProductParams) (*models.Product, error)
+	Get(productId string) (*models.Product, error)
+	GetAll(params ...*models.ListProductsParams) (*models.RetrieveAll[models.Product], error)
+	List(params ...*models.ListProductsParams) *Pager[models.Product]
+	Delete(productId string) error
+	GetPrices(productId string) (*models.RetrieveAll[models.ProductPrice], error)
+}
+
+// PricesAPI is implemented by *Prices.
+type PricesAPI interface {
+	Create(productPrice *models.ProductPriceParams, opts ...RequestOption) (*models.ProductPrice, error)
+	Update(productId string, data *models.UpdatePriceMetaDataParams) (*models.ProductPrice, error)
+	Get(productId string) (*models.ProductPrice, error)
+	GetAll(params ...*models.ListPricesParams) (*models.RetrieveAll[models.ProductPrice], error)
+	List(params ...*models.ListPricesParams) *Pager[models.ProductPrice]
+}
+
+// CheckoutsAPI is implemented by *Checkouts.
+type CheckoutsAPI interface {
+	Create(checkout *models.CheckoutParams, opts ...RequestOption) (*models.Checkout, error)
+	Get(checkoutId string) (*models.Checkout, error)
+	GetAll(params ...*models.ListCheckoutsParams) (*models.RetrieveAll[models.Checkout], error)
+	List(params ...*models.ListCheckoutsParams) *Pager[models.Checkout]
+	GetItems(checkoutId string) (*models.RetrieveAll[models.CheckoutItems], error)
+	Expire(checkoutId string) (*models.Checkout, error)
+}
+
+// PaymentLinksAPI is implemented by *PaymentLinks.
+type PaymentLinksAPI interface {
+	Create(paymentLink *models.CreatePaymentLinkParams, opts ...RequestOption) (*models.PaymentLink, error)
+	Update(paymentLinkId string, paymentLink *models.CreatePaymentLinkParams) (*models.PaymentLink, error)
+	Get(paymentLinkId string) (*models.PaymentLink, error)
+	GetAll(params ...*models.ListPaymentLinksParams) (*models.RetrieveAll[models.PaymentLink], error)
+	List(params ...*models.ListPaymentLinksParams) *Pager[models.PaymentLink]
+	GetItems(paymentLinkId string) (*models.RetrieveAll[models.PItemsData], error)
+}
+
+// WebhookAPI is implemented by *Webhook.
+type WebhookAPI interface {
+	SetupHandler(path string, handler EventHandler)
+	Handler(handler EventHandler) http.Handler
+	ContextHandler(handler ContextEventHandler) http.Handler
+	EnqueueHandler(enqueue EventEnqueuer) http.Handler
+	VerifySignature(payload []byte, signature string) error
+	VerifyEvent(ctx context.Context, payload []byte, signature string) (*models.WebhookEvent, error)
+	CheckLivemode(event *models.WebhookEvent) error
+}
+
+// ClientAPI aggregates the services of the client, it is implemented by *Client.
+type ClientAPI interface {
+	BalanceService() BalanceAPI
+	CustomersService() CustomersAPI
+	ProductsService() ProductsAPI
+	PricesService() PricesAPI
+	CheckoutsService() CheckoutsAPI
+	PaymentLinksService() PaymentLinksAPI
+	WebhookService() WebhookAPI
+}
+
+// the services implement their interface
+var (
+	_ BalanceAPI      = (*Balance)(nil)
+	_ CustomersAPI    = (*Customers)(nil)
+	_ ProductsAPI     = (*Products)(nil)
+	_ PricesAPI       = (*Prices)(nil)
+	_ CheckoutsAPI    = (*Checkouts)(nil)
+	_ PaymentLinksAPI = (*PaymentLinks)(nil)
+	_ WebhookAPI      = (*Webhook)(nil)
+	_ ClientAPI       = (*Client)(nil)
+)
+
+// BalanceService returns the balance service as an interface.
+func (c *Client) BalanceService() BalanceAPI { return c.Balance }
+
+// CustomersService returns the customers service as an interface.
+func (c *Client) CustomersService() CustomersAPI { return c.Customers }
+
+// ProductsService returns the products service as an interface.
+func (c *Client) ProductsService() ProductsAPI { return c.Products }
+
+// PricesService returns the prices service as an interface.
+func (c *Client) PricesService() PricesAPI { return c.Prices }
+
+// CheckoutsService returns the checkouts service as an interface.
+func (c *Client) CheckoutsService() CheckoutsAPI { return c.Checkouts }
+
+// PaymentLinksService returns the payment links service as an interface.
+func (c *Client) PaymentLinksService() PaymentLinksAPI { return c.PaymentLinks }
+
+// WebhookService returns the webhook service as an interface.
+func (c *Client) WebhookService() WebhookAPI { return c.Webhook }
@@ -0,0 +1,348 @@
+package chargily
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+//============ WEBHOOK EVENT JOURNAL =================//
+// With WithEventJournal every verified webhook request is recorded before its event is handled,
+// then recorded again with the outcome of the handling. The records can be listed and the events
+// sent again to the handler with SignReplay, e.g. by the "chargily events" command.
+
+// ErrEventNotFound is returned when the journal has no record of an event
+var ErrEventNotFound = errors.New("event not found in the journal")
+
+// EventOutcome is the state of a recorded event.
+type EventOutcome string
+
+// Outcomes of the recorded events
+const (
+	EventReceived EventOutcome = "received" // Verified, the handler didn't return yet.
+	EventHandled  EventOutcome = "handled"  // The EventHandler of Handler returned.
+	EventQueued   EventOutcome = "queued"   // Passed to the EventEnqueuer of EnqueueHandler, to be processed later.
+	EventFailed   EventOutcome = "failed"   // The handler panicked or returned an error.
+	EventRejected EventOutcome = "rejected" // Refused because of its livemode or its age, see WithLivemodeCheck and WithReplayProtection.
+)
+
+// EventRecord is a webhook request as recorded by an EventJournal.
+type EventRecord struct {
+	EventID    string       `json:"event_id"`
+	EventType  string       `json:"event_type"`
+	ReceivedAt time.Time    `json:"received_at"`
+	Payload    string       `json:"payload"`   // The raw body, as signed.
+	Signature  string       `json:"signature"` // The signature header.
+	Headers    http.Header  `json:"headers"`   // The other headers, without the cookies and credentials.
+	Replayed   bool         `json:"replayed,omitempty"`
+	Outcome    EventOutcome `json:"outcome"`
+	Error      string       `json:"error,omitempty"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// EventFilter selects the records returned by EventJournal.List, the zero fields select everything.
+type EventFilter struct {
+	Since   time.Time    // Received at or after.
+	Outcome EventOutcome // Current outcome.
+	Type    string       // Event type (e.g. "checkout.paid").
+}
+
+// Match reports whether a record is selected by the filter
+func (f EventFilter) Match(record EventRecord) bool {
+	return (f.Since.IsZero() || !record.ReceivedAt.Before(f.Since)) &&
+		(f.Outcome == "" || record.Outcome == f.Outcome) &&
+		(f.Type == "" || record.EventType == f.Type)
+}
+
+// EventJournal keeps the records of the webhook events, see WithEventJournal. Implement it over
+// a database to share the journal between several instances.
+type EventJournal interface {
+	// Record stores the current state of an event, it replaces the previous record of the same event.
+	Record(ctx context.Context, record EventRecord) error
+	// Get returns the current record of an event, or ErrEventNotFound.
+	Get(ctx context.Context, eventID string) (*EventRecord, error)
+	// List returns the current records selected by the filter, oldest received first.
+	List(ctx context.Context, filter EventFilter) ([]EventRecord, error)
+}
+
+// WithEventJournal records every verified webhook request in the journal before handling its
+// event, and records the outcome of the handling. A request that can't be recorded fails, so
+// Chargily sends it again.
+func WithEventJournal(journal EventJournal) ClientOption {
+	return func(c *Client) {
+		c.journal = journal
+	}
+}
+
+// journalHeaders are the headers left out of the records
+var journalHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", ReplayHeader}
+
+// newEventRecord records a verified request
+func newEventRecord(r *http.Request, payload []byte, signature string, receivedAt time.Time) EventRecord {
+	headers := r.Header.Clone()
+	for _, name := range journalHeaders {
+		headers.Del(name)
+	}
+	return EventRecord{
+		ReceivedAt: receivedAt,
+		Payload:    string(payload),
+		Signature:  signature,
+		Headers:    headers,
+		Replayed:   r.Header.Get(ReplayHeader) != "",
+		Outcome:    EventReceived,
+		UpdatedAt:  receivedAt,
+	}
+}
+
+// compactAfter is the number of records of a FileEventJournal above which it is compacted, once
+// most of them are replaced by a later record of their event
+const compactAfter = 1000
+
+// FileEventJournal is an EventJournal appending the records to a JSON Lines file, the last record
+// of an event is its current state. The offset of the current records is kept in memory, and the
+// file is rewritten without the replaced records once they are the majority.
+type FileEventJournal struct {
+	mu   sync.Mutex
+	path string
+
+	index   map[string]int64 // offset of the current record of every event, nil until the file is read
+	size    int64            // size of the file up to the end of its last complete record
+	records int              // records in the file, replaced ones included
+}
+
+// NewFileEventJournal creates the journal appending to the file at path, created when the first event is recorded.
+func NewFileEventJournal(path string) *FileEventJournal {
+	return &FileEventJournal{path: path}
+}
+
+func (j *FileEventJournal) Record(ctx context.Context, record EventRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.load(); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	// drop the part of a line left by a stopped process, the record would be appended to it
+	if err := file.Truncate(j.size); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(line); err != nil {
+		file.Close()
+		// the file may end with a part of the line, it is indexed again
+		j.index = nil
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		j.index = nil
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	j.index[record.EventID] = j.size
+	j.size += int64(len(line))
+	j.records++
+	if j.records > compactAfter && j.records > 2*len(j.index) {
+		// the record is stored, a failed compaction is attempted again by the next one
+		j.compact()
+	}
+	return nil
+}
+
+func (j *FileEventJournal) Get(ctx context.Context, eventID string) (*EventRecord, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.load(); err != nil {
+		return nil, err
+	}
+	offset, ok := j.index[eventID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotFound, eventID)
+	}
+
+	file, err := os.Open(j.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	line, err := bufio.NewReader(file).ReadBytes('\n')
+	if err != nil {
+		return nil, err
+	}
+	var record EventRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return nil, fmt.Errorf("event journal %s offset %d: %v", j.path, offset, err)
+	}
+	return &record, nil
+}
+
+func (j *FileEventJournal) List(ctx context.Context, filter EventFilter) ([]EventRecord, error) {
+	j.mu.Lock()
+	records, err := j.read()
+	j.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []EventRecord
+	for _, record := range records {
+		if filter.Match(record) {
+			selected = append(selected, record)
+		}
+	}
+	sort.Slice(selected, func(a, b int) bool {
+		return selected[a].ReceivedAt.Before(selected[b].ReceivedAt)
+	})
+	return selected, nil
+}
+
+// load indexes the file unless it is indexed and wasn't changed by another process since, j.mu is held
+func (j *FileEventJournal) load() error {
+	info, err := os.Stat(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		j.index, j.size, j.records = make(map[string]int64), 0, 0
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if j.index != nil && info.Size() == j.size {
+		return nil
+	}
+
+	index := make(map[string]int64)
+	size, records, err := j.scan(func(offset int64, record EventRecord) {
+		index[record.EventID] = offset
+	})
+	if err != nil {
+		return err
+	}
+	j.index, j.size, j.records = index, size, records
+	return nil
+}
+
+// read returns the current record of every event, j.mu is held
+func (j *FileEventJournal) read() (map[string]EventRecord, error) {
+	records := make(map[string]EventRecord)
+	_, _, err := j.scan(func(offset int64, record EventRecord) {
+		records[record.EventID] = record
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	return records, err
+}
+
+// scan passes every record of the file to fn with its offset, and returns the size of the file up
+// to the last complete record and the number of records, j.mu is held
+func (j *FileEventJournal) scan(fn func(offset int64, record EventRecord)) (size int64, records int, err error) {
+	file, err := os.Open(j.path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var invalid error
+	for line := 1; ; line++ {
+		raw, err := reader.ReadBytes('\n')
+		if len(raw) > 0 {
+			if invalid != nil {
+				return 0, 0, invalid
+			}
+			var record EventRecord
+			if err := json.Unmarshal(raw, &record); err != nil {
+				// an invalid last line was being written when the process stopped, it is skipped
+				// and left out of the size, to be truncated by the next record
+				invalid = fmt.Errorf("event journal %s line %d: %v", j.path, line, err)
+			} else {
+				fn(size, record)
+				records++
+				size += int64(len(raw))
+			}
+		}
+		if err == io.EOF {
+			return size, records, nil
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+}
+
+// compact rewrites the file with the current records only, j.mu is held
+func (j *FileEventJournal) compact() error {
+	current, err := j.read()
+	if err != nil {
+		return err
+	}
+	records := make([]EventRecord, 0, len(current))
+	for _, record := range current {
+		records = append(records, record)
+	}
+	// the current records keep their order
+	sort.Slice(records, func(a, b int) bool {
+		return j.index[records[a].EventID] < j.index[records[b].EventID]
+	})
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	index := make(map[string]int64, len(records))
+	var size int64
+	w := bufio.NewWriter(tmp)
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		index[record.EventID] = size
+		n, _ := w.Write(append(line, '\n'))
+		size += int64(n)
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), j.path); err != nil {
+		return err
+	}
+	j.index, j.size, j.records = index, size, len(records)
+	return nil
+}
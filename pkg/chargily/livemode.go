@@ -0,0 +1,98 @@
+package chargily
+
+import (
+	"log/slog"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// ErrLivemodeMismatch is returned when a response or a webhook event belongs to the other mode
+// than the client and the mismatches are rejected, see WithLivemodeCheck.
+var ErrLivemodeMismatch = utils.ErrLivemodeMismatch
+
+// LivemodeMismatch is a response or a webhook event whose livemode isn't the one of the client,
+// it is also the error returned when the mismatches are rejected.
+type LivemodeMismatch = utils.LivemodeMismatch
+
+// LivemodeHandler receives the livemode mismatches, see WithLivemodeCheck.
+type LivemodeHandler func(LivemodeMismatch)
+
+// LivemodeAction tells what the client does with a response or an event of the other mode.
+type LivemodeAction int
+
+const (
+	// FlagLivemode reports the mismatch to the handler, the response or the event is still used.
+	FlagLivemode LivemodeAction = iota
+	// RejectLivemode reports the mismatch to the handler, the call fails with ErrLivemodeMismatch
+	// and the webhook event is refused before reaching the EventHandler.
+	RejectLivemode
+)
+
+// WithLivemodeCheck sets what the client does when the livemode of a response or of a webhook
+// event isn't the one of its mode, e.g. a test mode event that must not fulfill a real order.
+// The mismatches are always reported to the handler, and with a nil handler they are logged as
+// warnings to the logger of WithLogger, if set. Without this option the mismatches are flagged in
+// the logs of WithLogger, and nothing is done without a logger.
+func WithLivemodeCheck(action LivemodeAction, handler LivemodeHandler) ClientOption {
+	return func(c *Client) {
+		c.livemodeAction = action
+		c.livemodeHandler = handler
+	}
+}
+
+// live reports whether the client talks to the production API
+func (c *Client) live() bool {
+	return c.mode == Prod
+}
+
+// checkLivemode reports a mismatch, and returns it as an error when the mismatches are rejected
+func (c *Client) checkLivemode(mismatch LivemodeMismatch) error {
+	handler := c.livemodeHandler
+	if handler == nil && c.logger != nil {
+		handler = logLivemode(c.logger)
+	}
+	if handler != nil {
+		handler(mismatch)
+	}
+
+	if c.livemodeAction == RejectLivemode {
+		return mismatch
+	}
+	return nil
+}
+
+// CheckLivemode compares the livemode of a webhook event with the mode of the client, see
+// WithLivemodeCheck. It returns an error when the event has the other mode and the mismatches
+// are rejected. The handler of Handler and SetupHandler calls it for every event.
+func (wh *Webhook) CheckLivemode(event *models.WebhookEvent) error {
+	if event.LiveMode == wh.client.live() {
+		return nil
+	}
+	return wh.client.checkLivemode(LivemodeMismatch{
+		Live:      wh.client.live(),
+		ObjectID:  event.Data.ID,
+		EventID:   event.ID,
+		EventType: event.Type,
+	})
+}
+
+// checksLivemode reports whether a mismatch has any effect: reported to a handler or to the
+// logger, or rejected
+func (c *Client) checksLivemode() bool {
+	return c.livemodeHandler != nil || c.logger != nil || c.livemodeAction == RejectLivemode
+}
+
+// logLivemode returns the livemode handler logging the mismatches as warnings
+func logLivemode(logger *slog.Logger) LivemodeHandler {
+	return func(mismatch LivemodeMismatch) {
+		logger.Warn("chargily: livemode doesn't match the mode of the client",
+			slog.Bool("client_livemode", mismatch.Live),
+			slog.String("resource", mismatch.Resource),
+			slog.String("operation", mismatch.Operation),
+			slog.String("object_id", mismatch.ObjectID),
+			slog.String("event_id", mismatch.EventID),
+			slog.String("event_type", mismatch.EventType),
+		)
+	}
+}
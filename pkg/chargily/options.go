@@ -0,0 +1,238 @@
+package chargily
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+)
+
+// ClientOption configures optional features of the Client, to be passed to NewClient.
+type ClientOption func(*Client)
+
+// WithRateLimit limits the requests sent by the client to rps requests per second on average,
+// with bursts of up to burst requests. Requests wait for their turn instead of failing, pass
+// WithContext to stop waiting. It panics if rps is not a positive number.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return WithRateLimiter(utils.NewRateLimiter(rps, burst))
+}
+
+// WithRateLimiter makes the client wait for the given limiter before every request,
+// passing the same limiter to several clients makes them share the limit.
+func WithRateLimiter(limiter *utils.RateLimiter) ClientOption {
+	return func(c *Client) {
+		c.senderOpts = append(c.senderOpts, utils.WithRateLimiter(limiter))
+	}
+}
+
+// WithIdempotencyCache keeps the response of the requests sent with an idempotency key for the
+// given window, repeated calls with the same key get the first response without reaching the API.
+func WithIdempotencyCache(window time.Duration) ClientOption {
+	return func(c *Client) {
+		c.senderOpts = append(c.senderOpts, utils.WithIdempotencyCache(utils.NewIdempotencyCache(window)))
+	}
+}
+
+// Middleware wraps the transport sending the requests of the client, see WithMiddleware.
+type Middleware = utils.Middleware
+
+// RoundTripperFunc adapts an ordinary function to the http.RoundTripper interface, to write middlewares.
+type RoundTripperFunc = utils.RoundTripperFunc
+
+// WithMiddleware adds middlewares between the client and the network, to add headers, audit,
+// trace, inject faults or mutate the requests. The first middleware is the outermost one.
+// Retried requests go through the middlewares again.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(c *Client) {
+		c.senderOpts = append(c.senderOpts, utils.WithMiddleware(middlewares...))
+	}
+}
+
+// ErrMissingAPIKey is returned when the credentials have no API key to give
+var ErrMissingAPIKey = utils.ErrMissingAPIKey
+
+// CredentialsProvider gives the API key of the client, see WithCredentials.
+type CredentialsProvider = utils.CredentialsProvider
+
+// CredentialsFunc adapts an ordinary function to the CredentialsProvider interface.
+type CredentialsFunc = utils.CredentialsFunc
+
+// StaticCredentials is an API key that never changes, the default credentials of a client.
+type StaticCredentials = utils.StaticCredentials
+
+// FileCredentials reads the API key from a file and reloads it when the file changes.
+type FileCredentials = utils.FileCredentials
+
+// EnvCredentials reads the API key from an environment variable on every call.
+func EnvCredentials(name string) CredentialsProvider {
+	return utils.EnvCredentials(name)
+}
+
+// NewFileCredentials reads the API key from the file at path, e.g. a mounted secret, and reads it
+// again whenever the file changes.
+func NewFileCredentials(path string) *FileCredentials {
+	return utils.NewFileCredentials(path)
+}
+
+// WithCredentials gets the API key from the provider for every request and every webhook
+// signature check, instead of the key given to NewClient which may then be empty. The key can
+// then be rotated without restarting the service.
+func WithCredentials(credentials CredentialsProvider) ClientOption {
+	return func(c *Client) {
+		c.credentials = credentials
+	}
+}
+
+// WithTimeout sets the time given to a call, the waits for the rate limiter and the retries
+// included. It is 10 seconds by default.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.senderOpts = append(c.senderOpts, utils.WithTimeout(timeout))
+	}
+}
+
+// WithMaxRetries sets how many times a throttled request is sent again (3 by default), 0 disables
+// the retries. The requests are only retried with a rate limit, which holds them until the API
+// accepts them again.
+func WithMaxRetries(retries int) ClientOption {
+	return func(c *Client) {
+		c.senderOpts = append(c.senderOpts, utils.WithMaxRetries(retries))
+	}
+}
+
+// WithBaseURL sends the requests to another API endpoint than the one of the mode, e.g. a proxy
+// or a mock server.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.endpoint = baseURL
+	}
+}
+
+// WithWebhookSecret checks the webhook signatures with the given secret instead of the API key.
+func WithWebhookSecret(secret string) ClientOption {
+	return func(c *Client) {
+		c.webhookSecret = secret
+	}
+}
+
+// WithTransport sends the requests of the client with the given transport instead of
+// http.DefaultTransport, e.g. to tune its connection pool or to share it between clients.
+// The middlewares of WithMiddleware wrap it.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.senderOpts = append(c.senderOpts, utils.WithTransport(transport))
+	}
+}
+
+// WithWebhookTracer notifies the tracer of the signature verification and of the handler
+// execution of every event received by the webhook handlers.
+func WithWebhookTracer(tracer WebhookTracer) ClientOption {
+	return func(c *Client) {
+		c.webhookTracer = tracer
+	}
+}
+
+// WithLogger logs every request sent by the client: method, path, status, duration and request ID
+// at debug level, and the failed ones at warn level with their error. The API key, the Authorization
+// header, emails and phone numbers are redacted from the logs.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithRedactedMetadataKeys hides the values of the given metadata keys from the logs and from Client.Redact.
+func WithRedactedMetadataKeys(keys ...string) ClientOption {
+	return func(c *Client) {
+		c.redactedKeys = append(c.redactedKeys, keys...)
+	}
+}
+
+// Drift is a difference between an API response and the model it is decoded into, see WithStrictDecoding.
+type Drift = utils.Drift
+
+// DriftHandler receives the drifts found by strict decoding.
+type DriftHandler = utils.DriftHandler
+
+// WithStrictDecoding checks every response against the model it is decoded into, and reports the
+// fields the model doesn't know and the fields whose type changed (e.g. a string where the model has
+// a number) to the handler, to find out when the API changes before it breaks the code using the
+// models. The responses are still decoded leniently. With a nil handler the drifts are logged as
+// warnings, to the logger of WithLogger if set and to the default slog logger otherwise.
+func WithStrictDecoding(handler DriftHandler) ClientOption {
+	return func(c *Client) {
+		c.strict = true
+		c.driftHandler = handler
+	}
+}
+
+// logDrift returns the drift handler logging the drifts as warnings
+func logDrift(logger *slog.Logger) DriftHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(drift Drift) {
+		logger.Warn("chargily: response drifted from its model",
+			slog.String("kind", string(drift.Kind)),
+			slog.String("model", drift.Model),
+			slog.String("path", drift.Path),
+			slog.String("expected", drift.Expected),
+			slog.String("got", drift.Got),
+			slog.String("resource", drift.Resource),
+			slog.String("operation", drift.Operation),
+		)
+	}
+}
+
+// RequestOption customizes a single request, to be passed to the methods accepting it.
+type RequestOption = utils.RequestOption
+
+// WithContext sends the request with the given context, canceling it stops the call, the waits
+// for the rate limiter included. The timeout set with WithTimeout still applies.
+func WithContext(ctx context.Context) RequestOption {
+	return utils.WithContext(ctx)
+}
+
+// WithContext returns a copy of the client sending every call with the given context, for the
+// methods not accepting request options.
+//
+//	ctx, cancel := context.WithCancel(r.Context())
+//	defer cancel()
+//	customer, err := client.WithContext(ctx).Customers.Get(customerId)
+func (c *Client) WithContext(ctx context.Context) *Client {
+	return c.withRequestOptions(utils.WithContext(ctx))
+}
+
+// WithIdempotencyKey attaches an Idempotency-Key header to the request, so retrying a call whose
+// response was lost does not create the object twice. Reuse the same key for every retry of a call.
+func WithIdempotencyKey(key string) RequestOption {
+	return utils.WithIdempotencyKey(key)
+}
+
+// WithNewIdempotencyKey attaches a freshly generated Idempotency-Key header to the request,
+// it covers the retries made by the client itself.
+func WithNewIdempotencyKey() RequestOption {
+	return utils.WithIdempotencyKey(utils.NewIdempotencyKey())
+}
+
+// IdempotencyKeyOf returns the idempotency key set by the request options, or an empty string,
+// e.g. to check the options recorded by a mock.
+func IdempotencyKeyOf(opts ...RequestOption) string {
+	return utils.IdempotencyKeyOf(opts...)
+}
+
+// NewIdempotencyKey generates a random idempotency key to be passed to WithIdempotencyKey.
+func NewIdempotencyKey() string {
+	return utils.NewIdempotencyKey()
+}
+
+// operation names the API call of a request for the middlewares, followed by the caller options
+func operation(resource, name, objectID string, opts ...RequestOption) []RequestOption {
+	return append([]RequestOption{utils.WithOperation(resource, name, objectID)}, opts...)
+}
@@ -0,0 +1,147 @@
+package chargily
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+//============ PAGINATION HELPERS =================//
+
+// NextPage retrieves the page that follows the given one using its next_page_url.
+// It returns nil without an error once the last page has been reached. The list params of the
+// first page are only applied as far as the API carries them in its next_page_url, walk through
+// a filtered list with the Pager returned by the List methods.
+func NextPage[T any](c *Client, page *models.RetrieveAll[T]) (*models.RetrieveAll[T], error) {
+	return nextPage(c, page, nil)
+}
+
+// nextPage retrieves the page following the given one, sending the query of the params with it
+// and dropping the objects that don't match them. params may be nil
+func nextPage[T any](c *Client, page *models.RetrieveAll[T], params models.ListFilter[T]) (*models.RetrieveAll[T], error) {
+	if page == nil || page.NextPageURL == nil || *page.NextPageURL == "" {
+		return nil, nil
+	}
+
+	// never send the API key to a host other than the Chargily API
+	next, err := url.Parse(*page.NextPageURL)
+	if err != nil || !strings.HasPrefix(next.String(), c.endpoint) || strings.Contains(next.Path, "..") {
+		return nil, fmt.Errorf("unexpected next page url: %s", *page.NextPageURL)
+	}
+
+	// the resource is the first segment of the path (e.g. "checkouts?page=2")
+	path, _, _ := strings.Cut(strings.TrimPrefix(next.String(), c.endpoint), "?")
+	resource, _, _ := strings.Cut(path, "/")
+
+	// the API doesn't always carry the query of the first page in its next_page_url
+	if params != nil {
+		query := next.Query()
+		for key, values := range params.Query() {
+			if key != "page" && !query.Has(key) {
+				query[key] = values
+			}
+		}
+		next.RawQuery = query.Encode()
+	}
+
+	var result models.RetrieveAll[T]
+	//send the request
+	err = c.rs.SendRequest("GET", next.String(), nil, &result, operation(resource, "list", "")...)
+
+	if err != nil {
+		return nil, err
+	}
+	filter(&result, params)
+	// Return the parsed page
+	return &result, nil
+}
+
+// Pager walks through the pages of a list, sending the list params with every page and dropping
+// the objects that don't match them. It is returned by the List methods of the services, and is
+// not safe for concurrent use.
+//
+// Because of the client side filtering a page may hold fewer objects than PerPage, or none at all
+// while the next pages still have matching objects. The Total and the page counts of a page are
+// the ones of the API, before the filtering.
+type Pager[T any] struct {
+	first func() (*models.RetrieveAll[T], error)
+	next  func(page *models.RetrieveAll[T]) (*models.RetrieveAll[T], error)
+	page  *models.RetrieveAll[T] // the last page returned
+	done  bool
+}
+
+// StaticPager returns a pager over the given pages, e.g. to be returned by the List methods of
+// the mocks of the services.
+func StaticPager[T any](pages ...*models.RetrieveAll[T]) *Pager[T] {
+	i := 0
+	next := func(*models.RetrieveAll[T]) (*models.RetrieveAll[T], error) {
+		if i == len(pages) {
+			return nil, nil
+		}
+		i++
+		return pages[i-1], nil
+	}
+	return &Pager[T]{first: func() (*models.RetrieveAll[T], error) { return next(nil) }, next: next}
+}
+
+// Next returns the next page of the list, the first one on the first call, and nil without an
+// error once the last page has been returned. A failed page can be retrieved again with Next.
+func (p *Pager[T]) Next() (*models.RetrieveAll[T], error) {
+	if p.done {
+		return nil, nil
+	}
+
+	var page *models.RetrieveAll[T]
+	var err error
+	if p.page == nil {
+		page, err = p.first()
+	} else {
+		page, err = p.next(p.page)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if page == nil {
+		p.done = true
+		return nil, nil
+	}
+	p.page = page
+	return page, nil
+}
+
+// ForEach calls fn for every object of the remaining pages, until the last one or until fn
+// returns an error.
+func (p *Pager[T]) ForEach(fn func(T) error) error {
+	for {
+		page, err := p.Next()
+		if err != nil || page == nil {
+			return err
+		}
+		for _, entry := range page.Data {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ForEach calls fn for every entry of a paginated list, starting from the given page
+// and following the next pages until the last one or until fn returns an error.
+func ForEach[T any](c *Client, first *models.RetrieveAll[T], fn func(T) error) error {
+	for page := first; page != nil; {
+		for _, entry := range page.Data {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+
+		next, err := NextPage(c, page)
+		if err != nil {
+			return err
+		}
+		page = next
+	}
+	return nil
+}
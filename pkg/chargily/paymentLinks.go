@@ -1,8 +1,6 @@
 package chargily
 
 import (
-	"strings"
-
 	"github.com/Chargily/chargily-pay-go/pkg/models"
 )
 
@@ -10,77 +8,39 @@ import (
 
 
 type PaymentLinks struct {
-    client * Client
+    resource[models.CreatePaymentLinkParams, models.CreatePaymentLinkParams, models.PaymentLink]
 }
 
-//create payment link
-func (p * PaymentLinks) Create(paymentLink *models.CreatePaymentLinkParams) (*models.PaymentLink, error) {
-    var link models.PaymentLink
-    //send the request 
-    err := p.client.rs.SendRequest("POST",  strings.Join([]string{p.client.endpoint, "payment-links"}, ""), paymentLink, &link)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed payment link object
-    return &link, nil
+//create payment link, pass WithIdempotencyKey to safely retry the call
+func (p * PaymentLinks) Create(paymentLink *models.CreatePaymentLinkParams, opts ...RequestOption) (*models.PaymentLink, error) {
+    return p.create(paymentLink, opts...)
 }
 
 // update a Payment Link
 func (p * PaymentLinks) Update(paymentLinkId string, paymentLink *models.CreatePaymentLinkParams) (*models.PaymentLink, error) {
-    var link models.PaymentLink
-    //send the request 
-    err := p.client.rs.SendRequest("POST",  strings.Join([]string{p.client.endpoint, "payment-links/", paymentLinkId}, ""), paymentLink, &link)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed payment link object
-    return &link, nil
+    return p.update(paymentLinkId, paymentLink)
 }
 
 
 // retrieve a payment link
 func (p * PaymentLinks) Get(paymentLinkId string) (*models.PaymentLink, error) {
-
-    var link models.PaymentLink
-    //send the request 
-    err := p.client.rs.SendRequest("GET",  strings.Join([]string{p.client.endpoint, "payment-links/", paymentLinkId }, ""), nil, &link)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed payment link object
-    return &link, nil
+    return p.get(paymentLinkId)
 }
 
 
-// retrieve all payment links
-func (p * PaymentLinks) GetAll() (*models.RetrieveAll[models.PaymentLink], error) {
-
-    var links models.RetrieveAll[models.PaymentLink]
-    //send the request 
-    err := p.client.rs.SendRequest("GET",  strings.Join([]string{p.client.endpoint, "payment-links"}, ""), nil, &links)
+// retrieve all payment links, optionally filtered by the params
+func (p * PaymentLinks) GetAll(params ...*models.ListPaymentLinksParams) (*models.RetrieveAll[models.PaymentLink], error) {
+    return p.list(first(params))
+}
 
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed payment link object
-    return &links, nil
+// walk through the pages of all the payment links, optionally filtered by the params
+func (p * PaymentLinks) List(params ...*models.ListPaymentLinksParams) *Pager[models.PaymentLink] {
+    return p.pager(first(params))
 }
 
 
 
 // retrieve a payment link's items
-func (p * PaymentLinks) GetItems(productId string) (*models.RetrieveAll[models.PItemsData], error) {
-
-    var items models.RetrieveAll[models.PItemsData]
-    //send the request 
-    err := p.client.rs.SendRequest("GET",  strings.Join([]string{p.client.endpoint, "payment-links/", productId , "/items"}, ""), nil, &items)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed payment link items object
-    return &items, nil
-}
\ No newline at end of file
+func (p * PaymentLinks) GetItems(paymentLinkId string) (*models.RetrieveAll[models.PItemsData], error) {
+    return subList[models.PItemsData](p.resource, paymentLinkId, "items")
+}
@@ -1,71 +1,39 @@
 package chargily
 
 import (
-	"strings"
-
 	"github.com/Chargily/chargily-pay-go/pkg/models"
 )
 
 //============PRICES AREA ==============//
 
 type Prices struct {
-    client * Client
+    resource[models.ProductPriceParams, models.UpdatePriceMetaDataParams, models.ProductPrice]
 }
 
-//Create Price of a product for a specific product
-func (p * Prices) Create(productPrice  * models.ProductPriceParams) (*models.ProductPrice, error) {
-    var price models.ProductPrice
-    //send the request 
-    err := p.client.rs.SendRequest("POST",  strings.Join([]string{p.client.endpoint, "prices"}, ""), productPrice, &price)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed product price object
-    return &price, nil
+//Create Price of a product for a specific product, pass WithIdempotencyKey to safely retry the call
+func (p * Prices) Create(productPrice  * models.ProductPriceParams, opts ...RequestOption) (*models.ProductPrice, error) {
+    return p.create(productPrice, opts...)
 } 
 
 
 // update the product price data (not the price itself as mentioned in the docs of Chargily) for a specific product
-func (p * Prices) Update(productId string, Data * models.UpdatePriceMetaDataParams ) (*models.ProductPrice, error) {
-    var price models.ProductPrice
-    //send the request 
-
-    err := p.client.rs.SendRequest("POST",  strings.Join([]string{p.client.endpoint, "prices/", productId}, ""), Data , &price)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed product price object
-    return &price, nil
+func (p * Prices) Update(priceId string, Data * models.UpdatePriceMetaDataParams ) (*models.ProductPrice, error) {
+    return p.update(priceId, Data)
 }
 
 
 // retrieve a price 
-func (p * Prices) Get(productId string) (*models.ProductPrice, error) {
-
-    var price models.ProductPrice
-    //send the request 
-    err := p.client.rs.SendRequest("GET",  strings.Join([]string{p.client.endpoint, "prices/", productId }, ""), nil, &price)
-
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed product price object
-    return &price, nil
+func (p * Prices) Get(priceId string) (*models.ProductPrice, error) {
+    return p.get(priceId)
 }
 
 
-// retrieve a list of all prices available 
-func (p * Prices) GetAll() (*models.RetrieveAll[models.ProductPrice], error) {
-
-    var prices models.RetrieveAll[models.ProductPrice]
-    //send the request 
-    err := p.client.rs.SendRequest("GET",  strings.Join([]string{p.client.endpoint, "prices"}, ""), nil, &prices)
+// retrieve a list of all prices available, optionally filtered by product, currency or creation time
+func (p * Prices) GetAll(params ...*models.ListPricesParams) (*models.RetrieveAll[models.ProductPrice], error) {
+    return p.list(first(params))
+}
 
-    if err!= nil {
-        return nil, err
-    }
-    // Return the parsed product price object
-    return &prices, nil
+// walk through the pages of all the prices, optionally filtered by the params
+func (p * Prices) List(params ...*models.ListPricesParams) *Pager[models.ProductPrice] {
+    return p.pager(first(params))
 }
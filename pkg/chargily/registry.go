@@ -0,0 +1,248 @@
+package chargily
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+//============ MULTI-TENANT CLIENT REGISTRY =================//
+
+// ErrUnknownTenant is returned by a KeyProvider when it has no API key for a tenant
+var ErrUnknownTenant = errors.New("unknown tenant")
+
+// ErrMissingTenant is returned when the tenant of a webhook request can't be found
+var ErrMissingTenant = errors.New("missing tenant")
+
+// TenantKey is the Chargily account of a tenant.
+type TenantKey struct {
+	APIKey string
+	Mode   string // "test" or "prod"
+}
+
+// KeyProvider returns the Chargily account of a tenant, e.g. from a database or a secret manager.
+// It returns an error wrapping ErrUnknownTenant for the tenants it doesn't know.
+type KeyProvider interface {
+	TenantKey(ctx context.Context, tenant string) (TenantKey, error)
+}
+
+// KeyProviderFunc adapts an ordinary function to the KeyProvider interface.
+type KeyProviderFunc func(ctx context.Context, tenant string) (TenantKey, error)
+
+func (f KeyProviderFunc) TenantKey(ctx context.Context, tenant string) (TenantKey, error) {
+	return f(ctx, tenant)
+}
+
+// StaticKeys is a KeyProvider holding the accounts of the tenants in memory.
+type StaticKeys map[string]TenantKey
+
+func (keys StaticKeys) TenantKey(ctx context.Context, tenant string) (TenantKey, error) {
+	key, ok := keys[tenant]
+	if !ok {
+		return TenantKey{}, fmt.Errorf("%w: %q", ErrUnknownTenant, tenant)
+	}
+	return key, nil
+}
+
+// RegistryOption configures a ClientRegistry, to be passed to NewClientRegistry.
+type RegistryOption func(*ClientRegistry)
+
+// WithClientOptions applies the options to the client of every tenant, e.g. a logger or
+// a rate limiter shared by all the tenants with WithRateLimiter.
+func WithClientOptions(opts ...ClientOption) RegistryOption {
+	return func(r *ClientRegistry) {
+		r.clientOpts = append(r.clientOpts, opts...)
+	}
+}
+
+// WithTenantRateLimit limits the requests of every tenant to rps requests per second with bursts
+// of up to burst requests. The limiter of a tenant outlives its client, so the limit still holds
+// after Invalidate. rps must be a positive number, see WithRateLimit.
+func WithTenantRateLimit(rps float64, burst int) RegistryOption {
+	utils.NewRateLimiter(rps, burst) // panics now on an invalid rps, rather than on the first tenant
+	return func(r *ClientRegistry) {
+		r.limiter = func() *utils.RateLimiter { return utils.NewRateLimiter(rps, burst) }
+	}
+}
+
+// WithRegistryTransport sends the requests of all the tenants with the given transport, the
+// registry creates a clone of http.DefaultTransport shared by its clients otherwise.
+func WithRegistryTransport(transport http.RoundTripper) RegistryOption {
+	return func(r *ClientRegistry) {
+		r.transport = transport
+	}
+}
+
+// ClientRegistry creates and caches a Client per tenant, for platforms where every merchant has
+// its own Chargily account. The clients are created on first use with the API key returned by the
+// key provider, and share the same HTTP transport.
+type ClientRegistry struct {
+	keys       KeyProvider
+	clientOpts []ClientOption
+	transport  http.RoundTripper
+	limiter    func() *utils.RateLimiter
+
+	mu       sync.Mutex
+	clients  map[string]*registryEntry
+	limiters map[string]*utils.RateLimiter
+}
+
+// registryEntry is the client of a tenant, ready once its creation is done
+type registryEntry struct {
+	ready  chan struct{}
+	client *Client
+	err    error
+}
+
+// NewClientRegistry creates a registry getting the API keys of the tenants from keys.
+func NewClientRegistry(keys KeyProvider, opts ...RegistryOption) *ClientRegistry {
+	r := &ClientRegistry{
+		keys:     keys,
+		clients:  map[string]*registryEntry{},
+		limiters: map[string]*utils.RateLimiter{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.transport == nil {
+		r.transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	return r
+}
+
+// Client returns the client of a tenant, creating it on first use. Concurrent calls for the same
+// tenant share the same creation, and failed creations are tried again on the next call.
+func (r *ClientRegistry) Client(ctx context.Context, tenant string) (*Client, error) {
+	r.mu.Lock()
+	entry, ok := r.clients[tenant]
+	if !ok {
+		entry = &registryEntry{ready: make(chan struct{})}
+		r.clients[tenant] = entry
+		r.mu.Unlock()
+
+		entry.client, entry.err = r.newClient(ctx, tenant)
+		if entry.err != nil {
+			r.mu.Lock()
+			if r.clients[tenant] == entry {
+				delete(r.clients, tenant)
+			}
+			r.mu.Unlock()
+		}
+		close(entry.ready)
+		return entry.client, entry.err
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-entry.ready:
+		return entry.client, entry.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate drops the cached client of a tenant, e.g. after its API key changed. The next call
+// to Client creates a new one.
+func (r *ClientRegistry) Invalidate(tenant string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.clients, tenant)
+}
+
+// newClient creates the client of a tenant with the options of the registry
+func (r *ClientRegistry) newClient(ctx context.Context, tenant string) (*Client, error) {
+	key, err := r.keys.TenantKey(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []ClientOption{WithTransport(r.transport)}
+	if limiter := r.tenantLimiter(tenant); limiter != nil {
+		opts = append(opts, WithRateLimiter(limiter))
+	}
+	opts = append(opts, r.clientOpts...)
+
+	client, err := NewClient(key.APIKey, key.Mode, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("tenant %q: %w", tenant, err)
+	}
+	return client, nil
+}
+
+// tenantLimiter returns the rate limiter of a tenant, nil without WithTenantRateLimit
+func (r *ClientRegistry) tenantLimiter(tenant string) *utils.RateLimiter {
+	if r.limiter == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	limiter, ok := r.limiters[tenant]
+	if !ok {
+		limiter = r.limiter()
+		r.limiters[tenant] = limiter
+	}
+	return limiter
+}
+
+// TenantResolver finds the tenant a webhook request is sent for.
+type TenantResolver func(r *http.Request) (string, error)
+
+// TenantFromPath takes the tenant from the path segment following prefix, e.g. "acme" for
+// "/webhooks/acme" with the prefix "/webhooks/".
+func TenantFromPath(prefix string) TenantResolver {
+	return func(r *http.Request) (string, error) {
+		rest, ok := strings.CutPrefix(r.URL.Path, prefix)
+		tenant, _, _ := strings.Cut(rest, "/")
+		if !ok || tenant == "" {
+			return "", fmt.Errorf("%w in path %s", ErrMissingTenant, r.URL.Path)
+		}
+		return tenant, nil
+	}
+}
+
+// TenantFromHeader takes the tenant from a request header, e.g. set by a gateway.
+func TenantFromHeader(name string) TenantResolver {
+	return func(r *http.Request) (string, error) {
+		tenant := r.Header.Get(name)
+		if tenant == "" {
+			return "", fmt.Errorf("%w in header %s", ErrMissingTenant, name)
+		}
+		return tenant, nil
+	}
+}
+
+// TenantEventHandler handles the webhook events of a tenant.
+type TenantEventHandler func(tenant, eventType string, event models.WebhookEvent)
+
+// WebhookHandler returns the http.Handler receiving the webhooks of every tenant: the tenant of
+// a request is found by resolve, and the signature is checked with the API key of that tenant
+// only, so a tenant can't send events on behalf of another one.
+func (r *ClientRegistry) WebhookHandler(resolve TenantResolver, handler TenantEventHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		tenant, err := resolve(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		client, err := r.Client(req.Context(), tenant)
+		if errors.Is(err, ErrUnknownTenant) {
+			http.Error(w, "Unknown tenant", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to load tenant", http.StatusInternalServerError)
+			return
+		}
+
+		client.Webhook.Handler(func(eventType string, event models.WebhookEvent) {
+			handler(tenant, eventType, event)
+		}).ServeHTTP(w, req)
+	})
+}
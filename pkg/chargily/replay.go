@@ -0,0 +1,221 @@
+package chargily
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+//============ WEBHOOK REPLAY PROTECTION =================//
+// A correctly signed request stays valid forever, so a captured request could be sent again.
+// With WithReplayProtection the events older than the tolerance are refused as stale, and the
+// IDs of the events are recorded so the same event is only handled once.
+
+// ErrStaleEvent is returned when a webhook event was created longer ago than the tolerance
+var ErrStaleEvent = errors.New("stale webhook event")
+
+// ErrReplayedEvent is returned when a webhook event was already received
+var ErrReplayedEvent = errors.New("replayed webhook event")
+
+// ErrInvalidPayload is returned when a signed webhook payload isn't a valid event
+var ErrInvalidPayload = errors.New("invalid webhook payload")
+
+// EventStore records the IDs of the received webhook events, see WithReplayProtection.
+type EventStore interface {
+	// Seen records the event ID and reports whether it was already recorded.
+	Seen(ctx context.Context, eventID string) (bool, error)
+	// Release forgets the event ID, the request of the event failed and Chargily sends it again.
+	Release(ctx context.Context, eventID string) error
+}
+
+// WithReplayProtection refuses the webhook events created more than tolerance ago (or ahead, for
+// clock skew) with ErrStaleEvent, and the events whose ID the store already recorded with
+// ErrReplayedEvent. A zero tolerance skips the age check and a nil store skips the ID check.
+// The store only needs to remember the IDs for the tolerance, older events being stale anyway.
+//
+// The age is measured from the creation of the event, not from its delivery: Chargily sends an
+// event again with its original created_at until it is acknowledged, so the retries later than
+// the tolerance are stale too. Pick a tolerance longer than the retries of Chargily. The handlers
+// answer 500 to the stale events, so Chargily keeps retrying, and record them as rejected in the
+// journal of WithEventJournal, to replay them once checked.
+func WithReplayProtection(tolerance time.Duration, store EventStore) ClientOption {
+	return func(c *Client) {
+		c.replayTolerance = tolerance
+		c.eventStore = store
+	}
+}
+
+// VerifyEvent checks the signature of a webhook payload, decodes its event and refuses it when
+// stale or replayed, see WithReplayProtection. The error is ErrInvalidSignature, ErrInvalidPayload,
+// ErrStaleEvent or ErrReplayedEvent, or the error of the credentials or of the store.
+// The ID of the returned event is recorded in the store, release it when the event can't be
+// handled so it is accepted when Chargily sends it again.
+func (wh *Webhook) VerifyEvent(ctx context.Context, payload []byte, signature string) (*models.WebhookEvent, error) {
+	return wh.verifyEvent(ctx, payload, signature, true)
+}
+
+// verifyEvent checks the signature of a payload and decodes its event, and only refuses the stale
+// and replayed events when fresh is set
+func (wh *Webhook) verifyEvent(ctx context.Context, payload []byte, signature string, fresh bool) (*models.WebhookEvent, error) {
+	if err := wh.VerifySignature(payload, signature); err != nil {
+		return nil, err
+	}
+
+	var event models.WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if !fresh {
+		return &event, nil
+	}
+
+	if tolerance := wh.client.replayTolerance; tolerance > 0 {
+		if event.CreatedAt.IsZero() {
+			return nil, fmt.Errorf("%w: event %s has no created_at", ErrStaleEvent, event.ID)
+		}
+		age := time.Since(event.CreatedAt.Time)
+		if age > tolerance || age < -tolerance {
+			return nil, fmt.Errorf("%w: event %s created at %s, outside the tolerance of %s",
+				ErrStaleEvent, event.ID, event.CreatedAt.Format(time.RFC3339), tolerance)
+		}
+	}
+
+	if store := wh.client.eventStore; store != nil {
+		seen, err := store.Seen(ctx, event.ID)
+		if err != nil {
+			return nil, fmt.Errorf("event store: %w", err)
+		}
+		if seen {
+			return nil, fmt.Errorf("%w: event %s", ErrReplayedEvent, event.ID)
+		}
+	}
+	return &event, nil
+}
+
+// releaseEvent forgets the ID of an event whose request failed, so it is handled when Chargily
+// sends it again, the failures are logged as the request fails anyway
+func (wh *Webhook) releaseEvent(ctx context.Context, eventID string) {
+	store := wh.client.eventStore
+	if store == nil {
+		return
+	}
+	if err := store.Release(ctx, eventID); err != nil {
+		logger := wh.client.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.Warn("chargily: failed to release the webhook event ID",
+			slog.String("event_id", eventID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// ReplayHeader carries the signature of a deliberate replay of a recorded event, see SignReplay.
+const ReplayHeader = "X-Chargily-Replay"
+
+// replayWindow is the age of the oldest replay signature accepted, unless WithReplayProtection
+// sets another tolerance
+const replayWindow = 5 * time.Minute
+
+// SignReplay signs the replay of a recorded payload at the given time, to send it again to a
+// webhook handler in the ReplayHeader along with its original signature (e.g. after fixing a bug
+// of the handler). The handler accepts the signed replays despite WithReplayProtection, only
+// the holders of the API key, or of the webhook secret, can sign them.
+func (wh *Webhook) SignReplay(payload []byte, at time.Time) (string, error) {
+	secret, err := wh.secret()
+	if err != nil {
+		return "", err
+	}
+	t := strconv.FormatInt(at.Unix(), 10)
+	return "t=" + t + ",v1=" + computeHMAC(append([]byte(t+"."), payload...), secret), nil
+}
+
+// verifyReplay checks the replay signature of a payload
+func (wh *Webhook) verifyReplay(payload []byte, header string) error {
+	var t, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			signature = value
+		}
+	}
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || signature == "" {
+		return fmt.Errorf("%w: malformed %s header", ErrInvalidSignature, ReplayHeader)
+	}
+
+	window := replayWindow
+	if wh.client.replayTolerance > 0 {
+		window = wh.client.replayTolerance
+	}
+	if age := time.Since(time.Unix(unix, 0)); age > window || age < -window {
+		return fmt.Errorf("%w: expired %s header", ErrInvalidSignature, ReplayHeader)
+	}
+
+	expected, err := wh.SignReplay(payload, time.Unix(unix, 0))
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal([]byte(expected), []byte("t="+t+",v1="+signature)) {
+		return fmt.Errorf("%w: %s header", ErrInvalidSignature, ReplayHeader)
+	}
+	return nil
+}
+
+// MemoryEventStore is an EventStore keeping the event IDs in memory, for a single instance.
+// Use a shared store (e.g. backed by a database or Redis) when several instances receive the webhooks.
+type MemoryEventStore struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	seen   map[string]time.Time // expiry of the recorded IDs
+	pruned time.Time
+}
+
+// NewMemoryEventStore creates the store remembering the event IDs for ttl, forever if ttl is 0.
+func NewMemoryEventStore(ttl time.Duration) *MemoryEventStore {
+	return &MemoryEventStore{ttl: ttl, seen: make(map[string]time.Time)}
+}
+
+func (s *MemoryEventStore) Seen(ctx context.Context, eventID string) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// drop the expired IDs, at most once per ttl
+	if s.ttl > 0 && now.Sub(s.pruned) > s.ttl {
+		for id, expiry := range s.seen {
+			if now.After(expiry) {
+				delete(s.seen, id)
+			}
+		}
+		s.pruned = now
+	}
+
+	if expiry, ok := s.seen[eventID]; ok && (s.ttl <= 0 || now.Before(expiry)) {
+		return true, nil
+	}
+	s.seen[eventID] = now.Add(s.ttl)
+	return false, nil
+}
+
+func (s *MemoryEventStore) Release(ctx context.Context, eventID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.seen, eventID)
+	return nil
+}
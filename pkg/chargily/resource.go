@@ -0,0 +1,138 @@
+package chargily
+
+import (
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+//============ GENERIC RESOURCE CORE =================//
+// Every service is built on resource, adding a new one only takes its params and result types:
+//
+//	type Coupons struct {
+//	    resource[models.CouponParams, models.CouponParams, models.Coupon]
+//	}
+//
+//	func (c *Coupons) Get(couponId string) (*models.Coupon, error) {
+//	    return c.get(couponId)
+//	}
+
+// ErrMissingID is returned when a call targeting an object is given an empty ID
+var ErrMissingID = utils.ErrMissingID
+
+// ErrInvalidID is returned when an object ID has characters the API never uses in its IDs,
+// such as "/" or "?" which would change the request
+var ErrInvalidID = utils.ErrInvalidID
+
+// resource sends the CRUD calls of an API resource, TCreate and TUpdate are the bodies of the
+// create and update calls and TResult the object returned by the API
+type resource[TCreate, TUpdate, TResult any] struct {
+	client *Client
+	name   string // path of the resource, relative to the API endpoint (e.g. "checkouts")
+}
+
+// newResource creates the resource with the given path for the client
+func newResource[TCreate, TUpdate, TResult any](client *Client, name string) resource[TCreate, TUpdate, TResult] {
+	return resource[TCreate, TUpdate, TResult]{client: client, name: name}
+}
+
+// create sends POST /<name>
+func (r resource[TCreate, TUpdate, TResult]) create(params *TCreate, opts ...RequestOption) (*TResult, error) {
+	return call[TResult](r.client, "POST", r.client.url(r.name), params, operation(r.name, "create", "", opts...))
+}
+
+// update sends POST /<name>/<id>
+func (r resource[TCreate, TUpdate, TResult]) update(id string, params *TUpdate) (*TResult, error) {
+	return call[TResult](r.client, "POST", r.object(id), params, operation(r.name, "update", id))
+}
+
+// get sends GET /<name>/<id>
+func (r resource[TCreate, TUpdate, TResult]) get(id string) (*TResult, error) {
+	return call[TResult](r.client, "GET", r.object(id), nil, operation(r.name, "get", id))
+}
+
+// list sends GET /<name>?<query>, it returns a page of the objects matching the params. params may be nil
+func (r resource[TCreate, TUpdate, TResult]) list(params models.ListFilter[TResult]) (*models.RetrieveAll[TResult], error) {
+	page, err := call[models.RetrieveAll[TResult]](r.client, "GET", r.client.url(r.name).Query(params), nil, operation(r.name, "list", ""))
+	if err != nil {
+		return nil, err
+	}
+	filter(page, params)
+	return page, nil
+}
+
+// delete sends DELETE /<name>/<id>
+func (r resource[TCreate, TUpdate, TResult]) delete(id string) error {
+	return send(r.client, "DELETE", r.object(id), nil, nil, operation(r.name, "delete", id))
+}
+
+// action sends POST /<name>/<id>/<action> (e.g. expire) and returns the updated object
+func (r resource[TCreate, TUpdate, TResult]) action(id, action string) (*TResult, error) {
+	return call[TResult](r.client, "POST", r.object(id).Path(action), nil, operation(r.name, action, id))
+}
+
+// object starts the URL of the object with the given ID, the ID format is checked when the URL is built
+func (r resource[TCreate, TUpdate, TResult]) object(id string) *utils.URLBuilder {
+	return r.client.url(r.name).ID(id)
+}
+
+// subList sends GET /<name>/<id>/<sub> and returns the first page of the sub objects (e.g. items)
+func subList[TItem, TCreate, TUpdate, TResult any](r resource[TCreate, TUpdate, TResult], id, sub string) (*models.RetrieveAll[TItem], error) {
+	return call[models.RetrieveAll[TItem]](r.client, "GET", r.object(id).Path(sub), nil, operation(r.name, sub, id))
+}
+
+// pager walks through the pages of the objects matching the params. params may be nil
+func (r resource[TCreate, TUpdate, TResult]) pager(params models.ListFilter[TResult]) *Pager[TResult] {
+	return &Pager[TResult]{
+		first: func() (*models.RetrieveAll[TResult], error) { return r.list(params) },
+		next: func(page *models.RetrieveAll[TResult]) (*models.RetrieveAll[TResult], error) {
+			return nextPage(r.client, page, params)
+		},
+	}
+}
+
+// filter drops the objects of the page not matching the params, for the criteria the API ignores.
+// The counts of the page (Total, PerPage...) are left as returned by the API
+func filter[T any](page *models.RetrieveAll[T], params models.ListFilter[T]) {
+	if params == nil {
+		return
+	}
+
+	matching := page.Data[:0]
+	for _, object := range page.Data {
+		if params.Match(object) {
+			matching = append(matching, object)
+		}
+	}
+	page.Data = matching
+}
+
+// first returns the optional params of a call, nil when they are not given
+func first[P any](params []*P) *P {
+	if len(params) == 0 {
+		return nil
+	}
+	return params[0]
+}
+
+// call sends a request to the built URL and decodes the response into a T
+func call[T any](c *Client, method string, u *utils.URLBuilder, body any, opts []RequestOption) (*T, error) {
+	var result T
+	if err := send(c, method, u, body, &result, opts); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// send sends a request to the built URL, nothing is sent if the URL is invalid
+func send(c *Client, method string, u *utils.URLBuilder, body any, result any, opts []RequestOption) error {
+	endpoint, err := u.Build()
+	if err != nil {
+		return err
+	}
+	return c.rs.SendRequest(method, endpoint, body, result, opts...)
+}
+
+// url starts the URL of an API path, its segments are escaped so an ID can't change the path
+func (c *Client) url(segments ...string) *utils.URLBuilder {
+	return utils.NewURLBuilder(c.endpoint).Path(segments...)
+}
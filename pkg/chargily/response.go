@@ -0,0 +1,46 @@
+package chargily
+
+import (
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+)
+
+//============ RAW RESPONSES =================//
+
+// Response is the HTTP response of an API call: status, headers, raw body, latency and request ID,
+// useful for debugging and for support requests to Chargily.
+type Response = utils.Response
+
+// CaptureResponse fills res with the HTTP response of the call, to be passed to the methods
+// accepting request options. res is filled for failed calls too.
+func CaptureResponse(res *Response) RequestOption {
+	return utils.CaptureResponse(res)
+}
+
+// WithResponse returns a copy of the client filling res with the HTTP response of every call it
+// sends, the response of the last call is kept. The copy shares the options of the client; use a
+// copy per goroutine.
+//
+//	var res chargily.Response
+//	checkout, err := client.WithResponse(&res).Checkouts.Get(checkoutId)
+//	log.Println(res.StatusCode, res.RequestID, res.Latency)
+func (c *Client) WithResponse(res *Response) *Client {
+	return c.withRequestOptions(utils.CaptureResponse(res))
+}
+
+// withRequestOptions returns a copy of the client sending every request with the given options
+func (c *Client) withRequestOptions(opts ...RequestOption) *Client {
+	copied := *c
+	copied.rs = &optionSender{rs: c.rs, opts: opts}
+	copied.initServices()
+	return &copied
+}
+
+// optionSender adds its options to every request sent through it
+type optionSender struct {
+	rs   utils.RequestSenderI
+	opts []RequestOption
+}
+
+func (s *optionSender) SendRequest(method, endpoint string, body interface{}, result interface{}, opts ...RequestOption) error {
+	return s.rs.SendRequest(method, endpoint, body, result, append(opts, s.opts...)...)
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrMissingAPIKey is returned when a credentials provider has no API key to give
+var ErrMissingAPIKey = errors.New("missing api key")
+
+// CredentialsProvider returns the API key of the account, it is called for every request and
+// every webhook signature check so the key can be rotated without restarting the service
+type CredentialsProvider interface {
+	APIKey(ctx context.Context) (string, error)
+}
+
+// CredentialsFunc adapts an ordinary function to the CredentialsProvider interface, e.g. to read
+// the key from a secret manager (cache it, the function is called for every request)
+type CredentialsFunc func(ctx context.Context) (string, error)
+
+func (f CredentialsFunc) APIKey(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticCredentials is an API key that never changes
+type StaticCredentials string
+
+func (c StaticCredentials) APIKey(ctx context.Context) (string, error) {
+	if c == "" {
+		return "", ErrMissingAPIKey
+	}
+	return string(c), nil
+}
+
+// EnvCredentials reads the API key from an environment variable on every call
+func EnvCredentials(name string) CredentialsProvider {
+	return CredentialsFunc(func(ctx context.Context) (string, error) {
+		key := os.Getenv(name)
+		if key == "" {
+			return "", fmt.Errorf("%w: %s is not set", ErrMissingAPIKey, name)
+		}
+		return key, nil
+	})
+}
+
+// FileCredentials reads the API key from a file, e.g. a mounted secret. The file is read again
+// when its modification time or size changes, the surrounding spaces and new lines are ignored.
+type FileCredentials struct {
+	path string
+
+	mu      sync.Mutex
+	key     string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileCredentials creates the provider reading the API key from the file at path
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{path: path}
+}
+
+func (c *FileCredentials) APIKey(ctx context.Context) (string, error) {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return "", fmt.Errorf("api key file: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.key != "" && info.ModTime().Equal(c.modTime) && info.Size() == c.size {
+		return c.key, nil
+	}
+
+	raw, err := os.ReadFile(c.path)
+	if err != nil {
+		return "", fmt.Errorf("api key file: %w", err)
+	}
+	key := string(bytes.TrimSpace(raw))
+	if key == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrMissingAPIKey, c.path)
+	}
+	c.key, c.modTime, c.size = key, info.ModTime(), info.Size()
+	return key, nil
+}
+
+// WithCredentials gets the API key of every request from the provider, instead of the key
+// given to NewRequestSender
+func WithCredentials(credentials CredentialsProvider) SenderOption {
+	return func(rs *RequestSender) {
+		rs.credentials = credentials
+	}
+}
@@ -0,0 +1,292 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DriftKind tells how a response differs from the model it is decoded into
+type DriftKind string
+
+// Kinds of drift
+const (
+	UnknownField DriftKind = "unknown_field" // The response has a field the model doesn't have.
+	TypeMismatch DriftKind = "type_mismatch" // A field of the response doesn't have the type of the model field.
+)
+
+// Drift is a difference between an API response and its model, found by strict decoding.
+// It never holds the values of the response, only their path and types.
+type Drift struct {
+	Kind      DriftKind
+	Path      string // Path of the field in the response (e.g. "data[0].active").
+	Model     string // Go type the response is decoded into (e.g. "models.Checkout").
+	Expected  string // Go type of the model field, empty for unknown fields.
+	Got       string // JSON type of the response field (string, number, bool, object, array).
+	Resource  string // The resource of the call (e.g. "checkouts"), when known.
+	Operation string // The operation of the call (e.g. "get"), when known.
+}
+
+func (d Drift) String() string {
+	if d.Kind == UnknownField {
+		return fmt.Sprintf("%s: unknown field %s (%s)", d.Model, d.Path, d.Got)
+	}
+	return fmt.Sprintf("%s: field %s is %s, expected %s", d.Model, d.Path, d.Got, d.Expected)
+}
+
+// DriftHandler receives the drifts found in the responses
+type DriftHandler func(Drift)
+
+// WithDriftHandler checks every successful response against the model it is decoded into and
+// reports the differences to the handler. The responses are still decoded leniently.
+func WithDriftHandler(handler DriftHandler) SenderOption {
+	return func(rs *RequestSender) {
+		rs.drift = handler
+	}
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// CheckDrift compares a JSON document with the model it is decoded into, model is a value or a
+// pointer of the model type. Like a decoder with DisallowUnknownFields it reports the fields the
+// model doesn't have, and also the fields whose JSON type doesn't fit the model, all of them
+// rather than the first one.
+func CheckDrift(raw []byte, model any) ([]Drift, error) {
+	t := reflect.TypeOf(model)
+	if t == nil {
+		return nil, nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	c := driftChecker{model: t.String()}
+	c.check("", doc, t)
+	return c.drifts, nil
+}
+
+type driftChecker struct {
+	model  string
+	drifts []Drift
+}
+
+func (c *driftChecker) report(kind DriftKind, path string, expected reflect.Type, v any) {
+	drift := Drift{Kind: kind, Path: strings.TrimPrefix(path, "."), Model: c.model, Got: jsonType(v)}
+	if expected != nil {
+		drift.Expected = expected.String()
+	}
+	c.drifts = append(c.drifts, drift)
+}
+
+// check compares the value at path with the type t, null fits every type like with encoding/json
+func (c *driftChecker) check(path string, v any, t reflect.Type) {
+	if v == nil {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// the types decoding themselves are checked by decoding the value into them
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		c.checkUnmarshaler(path, v, t)
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return
+
+	case reflect.Struct:
+		object, ok := v.(map[string]any)
+		if !ok {
+			c.report(TypeMismatch, path, t, v)
+			return
+		}
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(object) {
+			field, ok := lookupField(fields, key)
+			if !ok {
+				c.report(UnknownField, path+"."+key, nil, object[key])
+				continue
+			}
+			if field.quoted {
+				// the ",string" option wraps the value in a JSON string
+				if _, ok := object[key].(string); !ok && object[key] != nil {
+					c.report(TypeMismatch, path+"."+key, reflect.TypeOf(""), object[key])
+				}
+				continue
+			}
+			c.check(path+"."+key, object[key], field.typ)
+		}
+
+	case reflect.Map:
+		object, ok := v.(map[string]any)
+		if !ok {
+			c.report(TypeMismatch, path, t, v)
+			return
+		}
+		for _, key := range sortedKeys(object) {
+			c.check(path+"."+key, object[key], t.Elem())
+		}
+
+	case reflect.Slice, reflect.Array:
+		// []byte is decoded from a base64 string
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := v.(string); !ok {
+				c.report(TypeMismatch, path, t, v)
+			}
+			return
+		}
+		array, ok := v.([]any)
+		if !ok {
+			c.report(TypeMismatch, path, t, v)
+			return
+		}
+		for i, item := range array {
+			c.check(path+"["+strconv.Itoa(i)+"]", item, t.Elem())
+		}
+
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			c.report(TypeMismatch, path, t, v)
+		}
+
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			c.report(TypeMismatch, path, t, v)
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := v.(json.Number); !ok || !fitsInt(n, t.Bits()) {
+			c.report(TypeMismatch, path, t, v)
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := v.(json.Number); !ok || !fitsUint(n, t.Bits()) {
+			c.report(TypeMismatch, path, t, v)
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if _, ok := v.(json.Number); !ok {
+			c.report(TypeMismatch, path, t, v)
+		}
+	}
+}
+
+// checkUnmarshaler decodes the value with the UnmarshalJSON method of t, which knows the JSON
+// types it accepts (e.g. a number or a date string for a timestamp, but not an object)
+func (c *driftChecker) checkUnmarshaler(path string, v any, t reflect.Type) {
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = reflect.New(t).Interface().(json.Unmarshaler).UnmarshalJSON(data)
+	}
+	if err != nil {
+		c.report(TypeMismatch, path, t, v)
+	}
+}
+
+// jsonField is a field of a struct as seen by encoding/json
+type jsonField struct {
+	name   string
+	typ    reflect.Type
+	quoted bool
+}
+
+// jsonFields returns the fields decoded from JSON, with the fields of the embedded structs
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(embedded)...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		quoted := false
+		for _, opt := range strings.Split(opts, ",") {
+			quoted = quoted || opt == "string"
+		}
+		fields = append(fields, jsonField{name: name, typ: f.Type, quoted: quoted})
+	}
+	return fields
+}
+
+// lookupField finds the field of a JSON key, case insensitively like encoding/json
+func lookupField(fields []jsonField, key string) (jsonField, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+	return jsonField{}, false
+}
+
+func fitsInt(n json.Number, bits int) bool {
+	_, err := strconv.ParseInt(n.String(), 10, bits)
+	return err == nil
+}
+
+func fitsUint(n json.Number, bits int) bool {
+	_, err := strconv.ParseUint(n.String(), 10, bits)
+	return err == nil
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is used again with different parameters
+var ErrIdempotencyKeyReused = errors.New("idempotency key already used with different parameters")
+
+// IdempotencyCache remembers the response of the requests sent with an idempotency key,
+// so repeated calls within the window get the first response instead of creating a new object.
+// It is safe for concurrent use, concurrent calls with the same key wait for the first one.
+type IdempotencyCache struct {
+	mu      sync.Mutex
+	window  time.Duration
+	entries map[string]*idempotencyEntry
+}
+
+type idempotencyEntry struct {
+	done     chan struct{} // closed once the first call completed
+	bodyHash [sha256.Size]byte
+	raw      []byte
+	err      error
+	expires  time.Time
+}
+
+// NewIdempotencyCache creates a cache keeping the responses for the given window
+func NewIdempotencyCache(window time.Duration) *IdempotencyCache {
+	return &IdempotencyCache{
+		window:  window,
+		entries: map[string]*idempotencyEntry{},
+	}
+}
+
+// Do returns the response cached for the key, or calls send and caches its response.
+// Failed calls are not cached, so the request can be tried again with the same key.
+// A call waiting for a concurrent one with the same key returns ctx.Err() once ctx is done.
+func (c *IdempotencyCache) Do(ctx context.Context, key string, body []byte, send func() ([]byte, error)) ([]byte, error) {
+	hash := sha256.Sum256(body)
+
+	c.mu.Lock()
+	c.purge(time.Now())
+	if entry, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		if entry.bodyHash != hash {
+			return nil, ErrIdempotencyKeyReused
+		}
+		select {
+		case <-entry.done:
+			return entry.raw, entry.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	entry := &idempotencyEntry{done: make(chan struct{}), bodyHash: hash}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	entry.raw, entry.err = send()
+
+	c.mu.Lock()
+	if entry.err != nil {
+		delete(c.entries, key)
+	} else {
+		entry.expires = time.Now().Add(c.window)
+	}
+	c.mu.Unlock()
+
+	close(entry.done)
+	return entry.raw, entry.err
+}
+
+// purge drops the expired entries, the entries still in flight have no expiry yet
+func (c *IdempotencyCache) purge(now time.Time) {
+	for key, entry := range c.entries {
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
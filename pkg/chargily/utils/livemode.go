@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrLivemodeMismatch is returned when a response or a webhook event belongs to the other mode
+// than the client, e.g. a test mode event received by a production service
+var ErrLivemodeMismatch = errors.New("livemode mismatch")
+
+// LivemodeMismatch is a response or a webhook event whose livemode isn't the one of the client
+type LivemodeMismatch struct {
+	Live      bool   // The livemode of the client, the response or the event has the other one.
+	Resource  string // The resource of the call (e.g. "checkouts"), when known.
+	Operation string // The operation of the call (e.g. "get"), when known.
+	ObjectID  string // The ID of the returned object, or of the object of the event.
+	EventID   string // The ID of the webhook event, empty for the responses.
+	EventType string // The type of the webhook event, empty for the responses.
+}
+
+func (m LivemodeMismatch) Error() string {
+	mode, other := "test", "live"
+	if m.Live {
+		mode, other = "live", "test"
+	}
+	if m.EventID != "" {
+		return fmt.Sprintf("%s: %s event %s (%s) received in %s mode", ErrLivemodeMismatch, other, m.EventID, m.EventType, mode)
+	}
+	return fmt.Sprintf("%s: %s response to %s.%s %s in %s mode", ErrLivemodeMismatch, other, m.Resource, m.Operation, m.ObjectID, mode)
+}
+
+func (m LivemodeMismatch) Unwrap() error {
+	return ErrLivemodeMismatch
+}
+
+// LivemodeCheck receives the mismatches found in the responses, the call fails with the error it returns
+type LivemodeCheck func(LivemodeMismatch) error
+
+// livemodeGuard checks the livemode of the responses
+type livemodeGuard struct {
+	live  bool
+	check LivemodeCheck
+}
+
+// WithLivemodeCheck compares the livemode of every successful response with live, the mode of the
+// client, and passes the mismatches to check. The responses without a livemode are not checked.
+func WithLivemodeCheck(live bool, check LivemodeCheck) SenderOption {
+	return func(rs *RequestSender) {
+		rs.livemode = &livemodeGuard{live: live, check: check}
+	}
+}
+
+// livemodeObject is the part of an object, or of a list, read to check its livemode
+type livemodeObject struct {
+	ID       string          `json:"id"`
+	Livemode json.RawMessage `json:"livemode"`
+}
+
+// checkLivemode passes the response to the check when its livemode isn't the one of the client,
+// and for the lists every object of the page whose livemode isn't the one of the client
+func (g *livemodeGuard) checkLivemode(raw []byte, info RequestInfo) error {
+	var response struct {
+		livemodeObject
+		Data json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(raw, &response) != nil {
+		// the decoding error is returned by SendRequest
+		return nil
+	}
+
+	objects := []livemodeObject{response.livemodeObject}
+	var page []livemodeObject
+	if json.Unmarshal(response.Data, &page) == nil {
+		objects = append(objects, page...)
+	}
+	for i, object := range objects {
+		live, ok := parseLivemode(object.Livemode)
+		if !ok || live == g.live {
+			continue
+		}
+
+		objectID := object.ID
+		if i == 0 && info.ObjectID != "" {
+			objectID = info.ObjectID
+		}
+		err := g.check(LivemodeMismatch{
+			Live:      g.live,
+			Resource:  info.Resource,
+			Operation: info.Operation,
+			ObjectID:  objectID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseLivemode reads a livemode field, sent by the API as a JSON boolean, a "true" or "false"
+// string or as 0 or 1. ok is false when the field is missing or has another value.
+func parseLivemode(raw json.RawMessage) (live bool, ok bool) {
+	switch string(raw) {
+	case "true", `"true"`, "1", `"1"`:
+		return true, true
+	case "false", `"false"`, "0", `"0"`:
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maximum size of a failed response body copied to the logs
+const maxLoggedBodySize = 4096
+
+// request ID headers looked up in the responses, in order
+var requestIDHeaders = []string{"X-Request-Id", "Request-Id", "X-Correlation-Id"}
+
+// LoggingMiddleware logs every request: successful ones at debug level, failed ones at warn level
+// along with their (redacted) error. The Authorization header is never logged in clear, and the
+// API key it carries, which the credentials may have rotated, is hidden from the other values.
+func LoggingMiddleware(logger *slog.Logger, redactor *Redactor) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			res, err := next.RoundTrip(req)
+
+			redactor := redactor.WithSecrets(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", redactor.Redact(req.URL.RequestURI())),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if info, ok := RequestInfoFromContext(req.Context()); ok {
+				attrs = append(attrs,
+					slog.String("resource", info.Resource),
+					slog.String("operation", info.Operation),
+					slog.Int("attempt", info.Attempt),
+				)
+			}
+			attrs = append(attrs, headersAttr(req.Header, redactor))
+
+			level := slog.LevelDebug
+			if err != nil {
+				level = slog.LevelWarn
+				attrs = append(attrs, slog.String("error", redactor.Redact(err.Error())))
+			} else {
+				attrs = append(attrs, slog.Int("status", res.StatusCode), slog.String("request_id", requestID(res.Header)))
+				if res.StatusCode < 200 || res.StatusCode >= 300 {
+					level = slog.LevelWarn
+					attrs = append(attrs, slog.String("error", redactor.Redact(peekBody(res))))
+				}
+			}
+
+			logger.LogAttrs(req.Context(), level, "chargily request", attrs...)
+			return res, err
+		})
+	}
+}
+
+// headersAttr groups the request headers, hiding the credentials
+func headersAttr(header http.Header, redactor *Redactor) slog.Attr {
+	attrs := make([]any, 0, len(header))
+	for name := range header {
+		value := header.Get(name)
+		if name == "Authorization" {
+			value = Redacted
+		}
+		attrs = append(attrs, slog.String(name, redactor.Redact(value)))
+	}
+	return slog.Group("headers", attrs...)
+}
+
+// requestID returns the request ID set by the API on a response, if any
+func requestID(header http.Header) string {
+	for _, name := range requestIDHeaders {
+		if id := header.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// peekBody returns the beginning of a response body, leaving the body readable for the client
+func peekBody(res *http.Response) string {
+	body := res.Body
+	raw, _ := io.ReadAll(io.LimitReader(body, maxLoggedBodySize))
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(raw), body), body}
+	return string(raw)
+}
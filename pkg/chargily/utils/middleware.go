@@ -0,0 +1,39 @@
+package utils
+
+import "net/http"
+
+// Middleware wraps the transport sending the requests to the API, it can inspect or mutate
+// the requests and the responses, or answer without calling next at all.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts an ordinary function to the http.RoundTripper interface
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds middlewares around the transport of the request sender,
+// the first middleware is the outermost one and sees the requests first
+func WithMiddleware(middlewares ...Middleware) SenderOption {
+	return func(rs *RequestSender) {
+		rs.middlewares = append(rs.middlewares, middlewares...)
+	}
+}
+
+// WithTransport sends the requests with the given transport instead of http.DefaultTransport,
+// the middlewares wrap it. Several senders can share the same transport and its connections.
+func WithTransport(transport http.RoundTripper) SenderOption {
+	return func(rs *RequestSender) {
+		rs.hc.Transport = transport
+	}
+}
+
+// chain wraps the transport with the middlewares
+func chain(transport http.RoundTripper, middlewares []Middleware) http.RoundTripper {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+	return transport
+}
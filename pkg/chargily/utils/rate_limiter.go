@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the requests sent to the API.
+// It is safe for concurrent use and can be shared by several clients to enforce a common limit.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64   // tokens added per second
+	burst  float64   // maximum number of tokens in the bucket
+	tokens float64   // available tokens, negative when requests are waiting
+	last   time.Time // last time the tokens were refilled
+	pause  time.Time // no request is allowed before this time (set after a 429 response)
+}
+
+// NewRateLimiter creates a limiter allowing rps requests per second on average and up to burst requests at once.
+// It panics if rps is not a positive number.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if !(rps > 0) || math.IsInf(rps, 1) {
+		panic(fmt.Sprintf("utils: NewRateLimiter: rps must be a positive number, got %v", rps))
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request can be sent, or returns the context error if it is done first.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+
+	// reserve a token, a negative balance is the queue of waiting requests
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	if pause := l.pause.Sub(now); pause > delay {
+		delay = pause
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// give the reserved token back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Pause holds every request for the given duration, it is used when the API answers with 429 Too Many Requests.
+func (l *RateLimiter) Pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(d)
+	if until.After(l.pause) {
+		l.pause = until
+	}
+}
+
+// refill adds the tokens earned since the last refill
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens += elapsed * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// retryAfter parses the Retry-After header of a response, given either in seconds or as an HTTP date.
+// It falls back to one second when the header is missing or invalid.
+func retryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return time.Second
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Replacement texts of the redacted values
+const (
+	Redacted      = "[REDACTED]"
+	RedactedEmail = "[EMAIL]"
+	RedactedPhone = "[PHONE]"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// international numbers (+213 ..., 00213 ...) and local mobile numbers (05, 06, 07 followed by 8 digits)
+	phonePattern = regexp.MustCompile(`(?:\+|\b00)\d[\d .-]{6,}\d|\b0[5-7]\d{8}\b`)
+)
+
+// Redactor removes secrets and personal data from texts before they are logged
+type Redactor struct {
+	secrets      []string
+	metadataKeys map[string]bool
+}
+
+// NewRedactor creates a redactor hiding the given secrets (e.g. API keys), emails, phone numbers
+// and the values of the given metadata keys
+func NewRedactor(secrets []string, metadataKeys []string) *Redactor {
+	r := &Redactor{metadataKeys: map[string]bool{}}
+	for _, secret := range secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+	for _, key := range metadataKeys {
+		r.metadataKeys[key] = true
+	}
+	return r
+}
+
+// WithSecrets returns a copy of the redactor hiding the given secrets too
+func (r *Redactor) WithSecrets(secrets ...string) *Redactor {
+	copied := &Redactor{secrets: append([]string(nil), r.secrets...), metadataKeys: r.metadataKeys}
+	for _, secret := range secrets {
+		if secret != "" {
+			copied.secrets = append(copied.secrets, secret)
+		}
+	}
+	return copied
+}
+
+// Redact hides the secrets, emails and phone numbers of a text, and the values of the
+// configured metadata keys when the text is a JSON document
+func (r *Redactor) Redact(text string) string {
+	if len(r.metadataKeys) > 0 {
+		var document any
+		if json.Unmarshal([]byte(text), &document) == nil {
+			if b, err := json.Marshal(r.redactMetadata(document, false)); err == nil {
+				text = string(b)
+			}
+		}
+	}
+
+	for _, secret := range r.secrets {
+		text = strings.ReplaceAll(text, secret, Redacted)
+	}
+	text = emailPattern.ReplaceAllString(text, RedactedEmail)
+	return phonePattern.ReplaceAllString(text, RedactedPhone)
+}
+
+// redactMetadata walks a decoded JSON document and hides the configured keys of the metadata objects,
+// the validation errors of metadata fields (e.g. "metadata.tax_id") are hidden too
+func (r *Redactor) redactMetadata(value any, inMetadata bool) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if inMetadata && r.metadataKeys[key] {
+				v[key] = Redacted
+				continue
+			}
+			if name, ok := strings.CutPrefix(key, "metadata."); ok && r.metadataKeys[name] {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = r.redactMetadata(child, key == "metadata")
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = r.redactMetadata(child, false)
+		}
+	}
+	return value
+}
@@ -0,0 +1,31 @@
+package utils
+
+import "context"
+
+// RequestInfo describes the API call a request belongs to, middlewares get it from the request context
+type RequestInfo struct {
+	Resource  string // The resource of the call (e.g. "checkouts").
+	Operation string // The operation of the call (e.g. "create", "get", "list").
+	ObjectID  string // The ID of the targeted object, empty for create and list calls.
+	Attempt   int    // 0 for the first attempt, incremented on every retry.
+}
+
+type requestInfoKey struct{}
+
+// WithOperation names the API call a request belongs to
+func WithOperation(resource, operation, objectID string) RequestOption {
+	return func(o *requestOptions) {
+		o.info = RequestInfo{Resource: resource, Operation: operation, ObjectID: objectID}
+	}
+}
+
+// RequestInfoFromContext returns the description of the API call attached to a request context
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// contextWithRequestInfo attaches the description of the API call to a request context
+func contextWithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
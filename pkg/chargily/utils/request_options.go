@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// RequestOption customizes a single request
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	ctx            context.Context // the context of the caller, if set
+	idempotencyKey string
+	info           RequestInfo
+	response       *Response // filled with the HTTP response of the call, if set
+}
+
+// newRequestOptions applies the options of a request
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	options := &requestOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// WithContext sends the request with the context of the caller: canceling it stops the request,
+// including the waits for the rate limiter and the retries. The timeout of the sender still applies.
+func WithContext(ctx context.Context) RequestOption {
+	return func(o *requestOptions) {
+		o.ctx = ctx
+	}
+}
+
+// WithIdempotencyKey attaches the Idempotency-Key header to the request
+func WithIdempotencyKey(key string) RequestOption {
+	return func(o *requestOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// IdempotencyKeyOf returns the idempotency key set by the options, or an empty string
+func IdempotencyKeyOf(opts ...RequestOption) string {
+	return newRequestOptions(opts).idempotencyKey
+}
+
+// NewIdempotencyKey generates a random key (UUID version 4)
+func NewIdempotencyKey() string {
+	var b [16]byte
+	// crypto/rand never returns an error on the supported platforms
+	rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -5,54 +5,225 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 )
 
+// maximum number of times a request is retried after a 429 response when a rate limiter is set
+const maxRateLimitRetries = 3
+
+// DefaultTimeout is the time given to a call, retries included, unless another timeout is set
+const DefaultTimeout = 10 * time.Second
+
 // struct represents the request sender
 type RequestSender struct {
 	hc *http.Client
-	apiKey     string
+	credentials CredentialsProvider
+	limiter    *RateLimiter
+	idempotency *IdempotencyCache
+	middlewares []Middleware
+	drift       DriftHandler
+	livemode    *livemodeGuard
+	timeout     time.Duration
+	maxRetries  int
 }
 
 
 // available functions to use
 type RequestSenderI interface {
-	SendRequest(method, endpoint string, body interface{}, result interface{}) error
+	SendRequest(method, endpoint string, body interface{}, result interface{}, opts ...RequestOption) error
 }
 
 
-//create a new request sender 
-func NewRequestSender(apiKey string) RequestSenderI {
-    return &RequestSender{
+// SenderOption configures optional features of the request sender
+type SenderOption func(*RequestSender)
+
+// WithRateLimiter makes the request sender wait for the limiter before every request,
+// the same limiter can be passed to several senders to share the limit
+func WithRateLimiter(limiter *RateLimiter) SenderOption {
+	return func(rs *RequestSender) {
+		rs.limiter = limiter
+	}
+}
+
+// WithIdempotencyCache makes the requests carrying an idempotency key go through the cache
+func WithIdempotencyCache(cache *IdempotencyCache) SenderOption {
+	return func(rs *RequestSender) {
+		rs.idempotency = cache
+	}
+}
+
+// WithTimeout sets the time given to a call, the waits for the rate limiter and the retries included
+func WithTimeout(timeout time.Duration) SenderOption {
+	return func(rs *RequestSender) {
+		rs.timeout = timeout
+	}
+}
+
+// WithMaxRetries sets how many times a throttled request is sent again, 0 disables the retries.
+// The requests are only retried when a rate limiter is set, it holds them until the API accepts them.
+func WithMaxRetries(retries int) SenderOption {
+	return func(rs *RequestSender) {
+		rs.maxRetries = retries
+	}
+}
+
+
+//create a new request sender
+func NewRequestSender(apiKey string, opts ...SenderOption) RequestSenderI {
+    rs := &RequestSender{
         hc: 			&http.Client{},
-		apiKey: 		apiKey,
+		credentials: 	StaticCredentials(apiKey),
+		timeout:        DefaultTimeout,
+		maxRetries:     maxRateLimitRetries,
     }
+	for _, opt := range opts {
+		opt(rs)
+	}
+
+	// every request goes through the middlewares before reaching the network
+	if len(rs.middlewares) > 0 {
+		transport := rs.hc.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		rs.hc.Transport = chain(transport, rs.middlewares)
+	}
+	return rs
 }
 
 
 
 
 // sendRequest sends an HTTP request and decodes the JSON response into the provided result interface.
-func (rs * RequestSender) SendRequest(method, endpoint string, body interface{}, result interface{}) error {
-	// Create a context with a 3-second timeout for the request
-	ctx, cancel := context.WithTimeout(context.Background(), 10 * time.Second)
+func (rs * RequestSender) SendRequest(method, endpoint string, body interface{}, result interface{}, opts ...RequestOption) error {
+	options := newRequestOptions(opts)
+
+	// Create a context with a timeout for the request (10 seconds by default), from the context of the caller if given
+	parent := options.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, rs.timeout)
 	defer cancel()
 
-	var req *http.Request
-	var err error
+	var jsonBody []byte
 
 	// If the body is not nil, encode it as JSON
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %v", err)
 		}
+	}
+
+	send := func() ([]byte, error) {
+		return rs.send(ctx, method, endpoint, jsonBody, options)
+	}
+
+	var raw []byte
+	var err error
+	// Repeated calls with the same idempotency key get the result of the first one
+	if rs.idempotency != nil && options.idempotencyKey != "" {
+		raw, err = rs.idempotency.Do(ctx, method+" "+endpoint+" "+options.idempotencyKey, jsonBody, send)
+	} else {
+		raw, err = send()
+	}
+	if options.response != nil && options.response.StatusCode == 0 && raw != nil {
+		// answered by the idempotency cache
+		*options.response = Response{StatusCode: http.StatusOK, Status: "200 OK", Body: raw, Cached: true}
+	}
+	if err != nil {
+		return err
+	}
+
+
+	// Check that the response belongs to the mode of the client, if asked to
+	if rs.livemode != nil {
+		if err := rs.livemode.checkLivemode(raw, options.info); err != nil {
+			return err
+		}
+	}
+
+	// Report the differences between the response and its model, if asked to
+	if rs.drift != nil && result != nil {
+		rs.reportDrift(raw, result, options.info)
+	}
+
+	// Decode the response body into the provided result interface (JSON)
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode JSON response: %v", err)
+	}
+
+	return nil
+}
+
+
+// reportDrift passes the drifts of a response to the drift handler, with the call they belong to
+func (rs * RequestSender) reportDrift(raw []byte, result interface{}, info RequestInfo) {
+	drifts, err := CheckDrift(raw, result)
+	if err != nil {
+		// the decoding error is returned by SendRequest
+		return
+	}
+	for _, drift := range drifts {
+		drift.Resource = info.Resource
+		drift.Operation = info.Operation
+		rs.drift(drift)
+	}
+}
+
+
+// send sends the request, waiting for the rate limiter and retrying throttled requests,
+// and returns the body of the successful response
+func (rs * RequestSender) send(ctx context.Context, method, endpoint string, jsonBody []byte, options *requestOptions) ([]byte, error) {
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		// Wait for the rate limiter, if any
+		if rs.limiter != nil {
+			if err := rs.limiter.Wait(ctx); err != nil {
+				return nil, fmt.Errorf("rate limiter: %w", err)
+			}
+		}
+
+		// Let the middlewares know which call and which attempt the request belongs to
+		info := options.info
+		info.Attempt = attempt
+		res, err := rs.do(contextWithRequestInfo(ctx, info), method, endpoint, jsonBody, options)
+		if err != nil {
+			return nil, err
+		}
 
+		// Hold the following requests when the API asks to slow down, then try again
+		if res.StatusCode == http.StatusTooManyRequests && rs.limiter != nil && attempt < rs.maxRetries {
+			rs.limiter.Pause(retryAfter(res.Header))
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+			continue
+		}
+
+		options.capture(res, start, attempt+1)
+		raw, err := readResponse(res)
+		if options.response != nil {
+			options.response.Body = raw
+		}
+		return raw, err
+	}
+}
+
+
+// do creates and sends a single request
+func (rs * RequestSender) do(ctx context.Context, method, endpoint string, jsonBody []byte, options *requestOptions) (*http.Response, error) {
+	var req *http.Request
+	var err error
+
+	if jsonBody != nil {
 		// Create request with body
-		req, err = http.NewRequestWithContext(ctx, method, endpoint, bytes.NewBuffer(jsonBody))
+		req, err = http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(jsonBody))
 		if err!= nil {
-            return fmt.Errorf("failed to create request with body: %v", err)
+            return nil, fmt.Errorf("failed to create request with body: %v", err)
         }
 	} else {
 		// Create request without body
@@ -60,33 +231,46 @@ func (rs * RequestSender) SendRequest(method, endpoint string, body interface{},
 	}
 
 	if err != nil {
-		return fmt.Errorf("failed to create request: %v", err)
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	// Get the current API key, it may have been rotated since the last request
+	apiKey, err := rs.credentials.APIKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("credentials: %w", err)
 	}
 
 	// Set headers for the request
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+ rs.apiKey)
+	req.Header.Set("Authorization", "Bearer "+ apiKey)
+	if options.idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, options.idempotencyKey)
+	}
 
 	// Send the request using the provided HTTP client
 	res, err := rs.hc.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %v", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
+	return res, nil
+}
+
+
+// readResponse checks the status of the response and returns its body, for failed responses too
+func readResponse(res *http.Response) ([]byte, error) {
 	defer res.Body.Close()
 
+	raw, err := io.ReadAll(res.Body)
 
 	// Check if the status code is not in the 2xx range
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		var generalError GeneralError
-		json.NewDecoder(res.Body).Decode(&generalError)
-		return fmt.Errorf("failed with status: %s , \n error : %+v", res.Status, generalError)
+		json.Unmarshal(raw, &generalError)
+		return raw, fmt.Errorf("failed with status: %s , \n error : %+v", res.Status, generalError)
 	}
 
-
-	// Decode the response body into the provided result interface (JSON)
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode JSON response: %v", err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
 	}
-
-	return nil
+	return raw, nil
 }
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"net/http"
+	"time"
+)
+
+// Response is the HTTP response of an API call, kept for debugging and support requests
+type Response struct {
+	StatusCode int           // The status code of the response (e.g. 200).
+	Status     string        // The status line of the response (e.g. "200 OK").
+	Header     http.Header   // The headers of the response.
+	Body       []byte        // The raw body of the response, before decoding.
+	RequestID  string        // The request ID set by the API, empty if none.
+	Latency    time.Duration // The duration of the call, waits for the rate limiter and retries included.
+	Attempts   int           // The number of requests sent, more than 1 when throttled requests were retried.
+	Cached     bool          // Whether the response came from the idempotency cache, without reaching the API.
+}
+
+// CaptureResponse fills res with the HTTP response of the call, for both successful and failed calls.
+// res is left empty when the request could not be sent.
+func CaptureResponse(res *Response) RequestOption {
+	return func(o *requestOptions) {
+		o.response = res
+	}
+}
+
+// capture fills the captured response with the final response of the call, its body is set by readResponse
+func (o *requestOptions) capture(res *http.Response, start time.Time, attempts int) {
+	if o.response == nil {
+		return
+	}
+	*o.response = Response{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Header:     res.Header.Clone(),
+		RequestID:  requestID(res.Header),
+		Latency:    time.Since(start),
+		Attempts:   attempts,
+	}
+}
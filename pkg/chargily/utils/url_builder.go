@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ErrMissingID is returned when a call targeting an object is given an empty ID
+var ErrMissingID = errors.New("missing object id")
+
+// ErrInvalidID is returned when an object ID has characters the API never uses in its IDs
+var ErrInvalidID = errors.New("invalid object id")
+
+// maxIDLength is the length above which an object ID is rejected
+const maxIDLength = 128
+
+// idPattern matches the characters of the API object IDs (e.g. "01hj5n7cqpaf0mt2d0xx85tgz8")
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateID checks that an object ID can be used as a segment of a request path
+func ValidateID(id string) error {
+	if id == "" {
+		return ErrMissingID
+	}
+	if len(id) > maxIDLength || !idPattern.MatchString(id) {
+		return fmt.Errorf("%w: %q", ErrInvalidID, id)
+	}
+	return nil
+}
+
+// QueryParams is implemented by the typed parameters sent in the query string of a request
+type QueryParams interface {
+	Query() url.Values
+}
+
+// URLBuilder builds the URL of an API call from escaped path segments and query parameters,
+// the first error met is returned by Build
+type URLBuilder struct {
+	base     string
+	segments []string
+	query    url.Values
+	err      error
+}
+
+// NewURLBuilder starts the URL of a call relative to the base URL of the API
+func NewURLBuilder(base string) *URLBuilder {
+	return &URLBuilder{base: base, query: url.Values{}}
+}
+
+// Path appends path segments to the URL, each segment is escaped so it can't change the path
+func (b *URLBuilder) Path(segments ...string) *URLBuilder {
+	b.segments = append(b.segments, segments...)
+	return b
+}
+
+// ID appends the ID of an object to the path after checking its format with ValidateID
+func (b *URLBuilder) ID(id string) *URLBuilder {
+	if err := ValidateID(id); err != nil && b.err == nil {
+		b.err = err
+	}
+	return b.Path(id)
+}
+
+// Query adds the query parameters of params to the URL, params may be nil
+func (b *URLBuilder) Query(params QueryParams) *URLBuilder {
+	if params == nil {
+		return b
+	}
+	for key, values := range params.Query() {
+		for _, value := range values {
+			b.query.Add(key, value)
+		}
+	}
+	return b
+}
+
+// Set sets a query parameter of the URL
+func (b *URLBuilder) Set(key, value string) *URLBuilder {
+	b.query.Set(key, value)
+	return b
+}
+
+// Build returns the URL, or the first error met while building it
+func (b *URLBuilder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	u, err := url.Parse(b.base)
+	if err != nil {
+		return "", fmt.Errorf("invalid base url %s: %w", b.base, err)
+	}
+
+	escaped := make([]string, len(b.segments))
+	for i, segment := range b.segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	// the base URL ends with a slash (e.g. ".../api/v2/")
+	u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + "/" + strings.Join(escaped, "/")
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + strings.Join(b.segments, "/")
+
+	query := u.Query()
+	for key, values := range b.query {
+		query[key] = values
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
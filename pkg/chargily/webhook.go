@@ -1,26 +1,88 @@
 package chargily
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/Chargily/chargily-pay-go/pkg/models"
 )
 
+// ErrInvalidSignature is returned when the signature of a webhook request doesn't match its payload
+var ErrInvalidSignature = errors.New("invalid signature")
+
 type Webhook struct {
 	client * Client
 }
 
 type EventHandler func(eventType string ,event models.WebhookEvent)
 
+// ContextEventHandler handles the verified events of ContextHandler, with the context of the request
+// (and the span of the handling, see WithWebhookTracer). The request fails when it returns an error.
+type ContextEventHandler func(ctx context.Context, event models.WebhookEvent) error
+
+// EventEnqueuer receives the verified events of EnqueueHandler, the request is acknowledged when it returns nil
+type EventEnqueuer func(ctx context.Context, event models.WebhookEvent) error
+
+// WebhookTracer is notified of the steps of the webhook handler, see WithWebhookTracer.
+// Every Start method returns the function to call with the outcome of the step, StartHandle
+// also returns the context passed to the handler of the event.
+type WebhookTracer interface {
+	StartVerify(ctx context.Context) (end func(err error))
+	StartHandle(ctx context.Context, event *models.WebhookEvent) (handleCtx context.Context, end func(err error))
+}
+
 //wh : webhook
 func (wh * Webhook) SetupHandler(path string, handler EventHandler) {
-	http.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+	http.Handle(path, wh.Handler(handler))
+}
+
+
+// Handler returns the http.Handler verifying the signature of the webhook requests and calling
+// the handler with their event, to be mounted on any router
+func (wh * Webhook) Handler(handler EventHandler) http.Handler {
+	return wh.serve(EventHandled, func(ctx context.Context, event *models.WebhookEvent) error {
+		// Identify event type and call handler
+		handler(string(event.Type), *event)
+		return nil
+	})
+}
+
+
+// ContextHandler returns the http.Handler verifying the webhook requests like Handler, and calling
+// the handler with the context of the request and their event. The request fails with 500 when
+// the handler returns an error, so Chargily sends the event again.
+func (wh * Webhook) ContextHandler(handler ContextEventHandler) http.Handler {
+	return wh.serve(EventHandled, func(ctx context.Context, event *models.WebhookEvent) error {
+		return handler(ctx, *event)
+	})
+}
+
+
+// EnqueueHandler returns the http.Handler verifying the webhook requests like Handler, but which
+// only passes their event to enqueue, e.g. to persist it and process it later, and acknowledges
+// the request as soon as enqueue returns. Chargily sends the event again when enqueue fails.
+func (wh * Webhook) EnqueueHandler(enqueue EventEnqueuer) http.Handler {
+	return wh.serve(EventQueued, func(ctx context.Context, event *models.WebhookEvent) error {
+		return enqueue(ctx, *event)
+	})
+}
+
+
+// serve verifies the webhook requests and passes their event to handle, the outcome of a handled
+// event is recorded in the journal, if any
+func (wh * Webhook) serve(outcome EventOutcome, handle func(ctx context.Context, event *models.WebhookEvent) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAt := time.Now()
+
 		// Extract signature
 		signature := r.Header.Get("signature")
 
@@ -40,27 +102,99 @@ func (wh * Webhook) SetupHandler(path string, handler EventHandler) {
 		}
 
 
-		// Verify signature
-		err = wh.VerifySignature(payload, signature)
-		if err != nil {
+		// Verify signature, parse JSON payload and refuse the stale and replayed events, unless
+		// the replay is signed
+		endVerify := wh.startVerify(r.Context())
+		replay := r.Header.Get(ReplayHeader)
+		if replay != "" {
+			err = wh.verifyReplay(payload, replay)
+		}
+		var verified *models.WebhookEvent
+		if err == nil {
+			verified, err = wh.verifyEvent(r.Context(), payload, signature, replay == "")
+		}
+		endVerify(err)
+		switch {
+		case errors.Is(err, ErrInvalidSignature):
 			http.Error(w, err.Error(), http.StatusForbidden)
 			return
+		case errors.Is(err, ErrInvalidPayload):
+			http.Error(w, "Invalid JSON payload", http.StatusInternalServerError)
+			return
+		case errors.Is(err, ErrStaleEvent):
+			// a late retry of Chargily is as old as a captured request: it is recorded as rejected,
+			// to be replayed once checked, and fails with 500 rather than 400 so Chargily keeps
+			// sending it
+			var stale models.WebhookEvent
+			json.Unmarshal(payload, &stale)
+			record := newEventRecord(r, payload, signature, receivedAt)
+			record.EventID, record.EventType = stale.ID, stale.Type
+			wh.recordOutcome(r.Context(), record, EventRejected, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		case errors.Is(err, ErrReplayedEvent):
+			// already received, acknowledged again so it isn't sent anymore
+			w.WriteHeader(http.StatusOK)
+			return
+		case err != nil:
+			http.Error(w, "Failed to verify event", http.StatusInternalServerError)
+			return
 		}
+		event := *verified
 
-		// Parse JSON payload
-		var event models.WebhookEvent
-		if err := json.Unmarshal(payload, &event); err != nil {
-			http.Error(w, "Invalid JSON payload", http.StatusInternalServerError)
+		// The event ID recorded by the replay protection is released when the request fails,
+		// so the event is handled when Chargily sends it again
+		acknowledged := false
+		if replay == "" {
+			defer func() {
+				if !acknowledged {
+					wh.releaseEvent(r.Context(), event.ID)
+				}
+			}()
+		}
+
+		// Record the request before handling it
+		record := newEventRecord(r, payload, signature, receivedAt)
+		record.EventID, record.EventType = event.ID, event.Type
+		if outcome == EventQueued {
+			// the worker processing the event may record its outcome before enqueue returns
+			record.Outcome = EventQueued
+		}
+		if err := wh.record(r.Context(), record); err != nil {
+			http.Error(w, "Failed to record event", http.StatusInternalServerError)
 			return
 		}
 
-		// Identify event type and call handler
-  		eventType := string(event.Type)
+		// Refuse the events of the other mode, if asked to
+		if err := wh.CheckLivemode(&event); err != nil {
+			wh.recordOutcome(r.Context(), record, EventRejected, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-		// Call user-defined handler
-		handler(eventType, event)
+		// Call user-defined handler, within the span of the handling if traced
+		ctx, endHandle := wh.startHandle(r.Context(), &event)
+		defer func() {
+			if p := recover(); p != nil {
+				err := fmt.Errorf("panic: %v", p)
+				endHandle(err)
+				wh.recordOutcome(r.Context(), record, EventFailed, err)
+				panic(p)
+			}
+		}()
+		err = handle(ctx, &event)
+		endHandle(err)
+		if err != nil {
+			wh.recordOutcome(r.Context(), record, EventFailed, err)
+			http.Error(w, "Failed to handle event", http.StatusInternalServerError)
+			return
+		}
+		if outcome != EventQueued {
+			wh.recordOutcome(r.Context(), record, outcome, nil)
+		}
 
 		// Respond with 200 OK
+		acknowledged = true
 		w.WriteHeader(http.StatusOK)
 	})
 }
@@ -69,18 +203,85 @@ func (wh * Webhook) SetupHandler(path string, handler EventHandler) {
 
 // Reuseable signature verifier function 
 func (wh * Webhook) VerifySignature(payload []byte, signature string) error{
+	secret, err := wh.secret()
+	if err != nil {
+		return err
+	}
+
 	//compute the HMAC signature
-	computedSignature := computeHMAC(payload, wh.client.apiKey)
+	computedSignature := computeHMAC(payload, secret)
 	// Compare the computed signature with the received signature
 	if !hmac.Equal([]byte(computedSignature), []byte(signature)) {
 		// If they don't match, return an error indicating invalid signature
-		return errors.New("invalid signature")
+		return ErrInvalidSignature
 	}
 	// If they match, return nil indicating valid signature
 	return nil; 
 }
 
 
+// record stores the record of a request in the journal, if any
+func (wh * Webhook) record(ctx context.Context, record EventRecord) error {
+	if wh.client.journal == nil {
+		return nil
+	}
+	return wh.client.journal.Record(ctx, record)
+}
+
+
+// recordOutcome records the outcome of an event, the failures to record it are logged as the
+// event is already handled
+func (wh * Webhook) recordOutcome(ctx context.Context, record EventRecord, outcome EventOutcome, cause error) {
+	record.Outcome, record.UpdatedAt = outcome, time.Now()
+	if cause != nil {
+		record.Error = cause.Error()
+	}
+	if err := wh.record(ctx, record); err != nil {
+		logger := wh.client.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.Warn("chargily: failed to record the webhook event outcome",
+			slog.String("event_id", record.EventID),
+			slog.String("outcome", string(outcome)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+
+// secret returns the key of the signatures: the webhook secret, or the current API key which may have been rotated
+func (wh * Webhook) secret() (string, error) {
+	if wh.client.webhookSecret != "" {
+		return wh.client.webhookSecret, nil
+	}
+	apiKey, err := wh.client.credentials.APIKey(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("credentials: %w", err)
+	}
+	return apiKey, nil
+}
+
+
+// startVerify notifies the tracer, if any, that a signature verification starts
+func (wh * Webhook) startVerify(ctx context.Context) func(error) {
+	if wh.client.webhookTracer == nil {
+		return func(error) {}
+	}
+	return wh.client.webhookTracer.StartVerify(ctx)
+}
+
+
+// startHandle notifies the tracer, if any, that the handler of an event is called, and returns
+// the context of the handler
+func (wh * Webhook) startHandle(ctx context.Context, event *models.WebhookEvent) (context.Context, func(error)) {
+	if wh.client.webhookTracer == nil {
+		return ctx, func(error) {}
+	}
+	return wh.client.webhookTracer.StartHandle(ctx, event)
+}
+
+
 // Helper function to compute HMAC
 func computeHMAC(data []byte, key string) string {
 	// Create a new HMAC hash using SHA256 and the provided API key
@@ -0,0 +1,66 @@
+// Package chargilymock provides testify mocks of the chargily service interfaces, to test the code
+// depending on chargily.ClientAPI or on a single service interface without network access.
+//
+// The mocks are generated by mockery from the interfaces, run go generate after changing them.
+// The request options passed to the Create methods are recorded after the params, match them
+// with mock.Anything, or inspect them with chargily.IdempotencyKeyOf:
+//
+//	customers := &chargilymock.Customers{}
+//	customers.On("Create", mock.Anything).Return(&models.Customer{ID: "cus_1"}, nil)
+//	customers.On("Create", mock.Anything, mock.MatchedBy(func(opt chargily.RequestOption) bool {
+//		return chargily.IdempotencyKeyOf(opt) != ""
+//	})).Return(&models.Customer{ID: "cus_2"}, nil)
+package chargilymock
+
+//go:generate go run github.com/vektra/mockery/v2@v2.53.5
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+)
+
+// Client is a chargily.ClientAPI made of mocks, every service is created by NewClient.
+type Client struct {
+	Balance      *Balance
+	Customers    *Customers
+	Products     *Products
+	Prices       *Prices
+	Checkouts    *Checkouts
+	PaymentLinks *PaymentLinks
+	Webhook      *Webhook
+}
+
+var _ chargily.ClientAPI = (*Client)(nil)
+
+// NewClient creates a client whose services are all mocks.
+func NewClient() *Client {
+	return &Client{
+		Balance:      &Balance{},
+		Customers:    &Customers{},
+		Products:     &Products{},
+		Prices:       &Prices{},
+		Checkouts:    &Checkouts{},
+		PaymentLinks: &PaymentLinks{},
+		Webhook:      &Webhook{},
+	}
+}
+
+// AssertExpectations asserts the expectations of every service of the client.
+func (c *Client) AssertExpectations(t mock.TestingT) bool {
+	ok := c.Balance.AssertExpectations(t)
+	ok = c.Customers.AssertExpectations(t) && ok
+	ok = c.Products.AssertExpectations(t) && ok
+	ok = c.Prices.AssertExpectations(t) && ok
+	ok = c.Checkouts.AssertExpectations(t) && ok
+	ok = c.PaymentLinks.AssertExpectations(t) && ok
+	return c.Webhook.AssertExpectations(t) && ok
+}
+
+func (c *Client) BalanceService() chargily.BalanceAPI           { return c.Balance }
+func (c *Client) CustomersService() chargily.CustomersAPI       { return c.Customers }
+func (c *Client) ProductsService() chargily.ProductsAPI         { return c.Products }
+func (c *Client) PricesService() chargily.PricesAPI             { return c.Prices }
+func (c *Client) CheckoutsService() chargily.CheckoutsAPI       { return c.Checkouts }
+func (c *Client) PaymentLinksService() chargily.PaymentLinksAPI { return c.PaymentLinks }
+func (c *Client) WebhookService() chargily.WebhookAPI           { return c.Webhook }
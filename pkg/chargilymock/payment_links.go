@@ -0,0 +1,417 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package chargilymock
+
+import (
+	chargily "github.com/Chargily/chargily-pay-go/pkg/chargily"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// PaymentLinks is an autogenerated mock type for the PaymentLinksAPI type
+type PaymentLinks struct {
+	mock.Mock
+}
+
+type PaymentLinks_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *PaymentLinks) EXPECT() *PaymentLinks_Expecter {
+	return &PaymentLinks_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: paymentLink, opts
+func (_m *PaymentLinks) Create(paymentLink *models.CreatePaymentLinkParams, opts ...chargily.RequestOption) (*models.PaymentLink, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, paymentLink)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *models.PaymentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*models.CreatePaymentLinkParams, ...chargily.RequestOption) (*models.PaymentLink, error)); ok {
+		return rf(paymentLink, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(*models.CreatePaymentLinkParams, ...chargily.RequestOption) *models.PaymentLink); ok {
+		r0 = rf(paymentLink, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*models.CreatePaymentLinkParams, ...chargily.RequestOption) error); ok {
+		r1 = rf(paymentLink, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaymentLinks_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type PaymentLinks_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - paymentLink *models.CreatePaymentLinkParams
+//   - opts ...chargily.RequestOption
+func (_e *PaymentLinks_Expecter) Create(paymentLink interface{}, opts ...interface{}) *PaymentLinks_Create_Call {
+	return &PaymentLinks_Create_Call{Call: _e.mock.On("Create",
+		append([]interface{}{paymentLink}, opts...)...)}
+}
+
+func (_c *PaymentLinks_Create_Call) Run(run func(paymentLink *models.CreatePaymentLinkParams, opts ...chargily.RequestOption)) *PaymentLinks_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]chargily.RequestOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(chargily.RequestOption)
+			}
+		}
+		run(args[0].(*models.CreatePaymentLinkParams), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *PaymentLinks_Create_Call) Return(_a0 *models.PaymentLink, _a1 error) *PaymentLinks_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PaymentLinks_Create_Call) RunAndReturn(run func(*models.CreatePaymentLinkParams, ...chargily.RequestOption) (*models.PaymentLink, error)) *PaymentLinks_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: paymentLinkId
+func (_m *PaymentLinks) Get(paymentLinkId string) (*models.PaymentLink, error) {
+	ret := _m.Called(paymentLinkId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.PaymentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.PaymentLink, error)); ok {
+		return rf(paymentLinkId)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.PaymentLink); ok {
+		r0 = rf(paymentLinkId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(paymentLinkId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaymentLinks_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type PaymentLinks_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - paymentLinkId string
+func (_e *PaymentLinks_Expecter) Get(paymentLinkId interface{}) *PaymentLinks_Get_Call {
+	return &PaymentLinks_Get_Call{Call: _e.mock.On("Get", paymentLinkId)}
+}
+
+func (_c *PaymentLinks_Get_Call) Run(run func(paymentLinkId string)) *PaymentLinks_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *PaymentLinks_Get_Call) Return(_a0 *models.PaymentLink, _a1 error) *PaymentLinks_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PaymentLinks_Get_Call) RunAndReturn(run func(string) (*models.PaymentLink, error)) *PaymentLinks_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAll provides a mock function with given fields: params
+func (_m *PaymentLinks) GetAll(params ...*models.ListPaymentLinksParams) (*models.RetrieveAll[models.PaymentLink], error) {
+	_va := make([]interface{}, len(params))
+	for _i := range params {
+		_va[_i] = params[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAll")
+	}
+
+	var r0 *models.RetrieveAll[models.PaymentLink]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(...*models.ListPaymentLinksParams) (*models.RetrieveAll[models.PaymentLink], error)); ok {
+		return rf(params...)
+	}
+	if rf, ok := ret.Get(0).(func(...*models.ListPaymentLinksParams) *models.RetrieveAll[models.PaymentLink]); ok {
+		r0 = rf(params...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RetrieveAll[models.PaymentLink])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(...*models.ListPaymentLinksParams) error); ok {
+		r1 = rf(params...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaymentLinks_GetAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAll'
+type PaymentLinks_GetAll_Call struct {
+	*mock.Call
+}
+
+// GetAll is a helper method to define mock.On call
+//   - params ...*models.ListPaymentLinksParams
+func (_e *PaymentLinks_Expecter) GetAll(params ...interface{}) *PaymentLinks_GetAll_Call {
+	return &PaymentLinks_GetAll_Call{Call: _e.mock.On("GetAll",
+		append([]interface{}{}, params...)...)}
+}
+
+func (_c *PaymentLinks_GetAll_Call) Run(run func(params ...*models.ListPaymentLinksParams)) *PaymentLinks_GetAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]*models.ListPaymentLinksParams, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(*models.ListPaymentLinksParams)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *PaymentLinks_GetAll_Call) Return(_a0 *models.RetrieveAll[models.PaymentLink], _a1 error) *PaymentLinks_GetAll_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PaymentLinks_GetAll_Call) RunAndReturn(run func(...*models.ListPaymentLinksParams) (*models.RetrieveAll[models.PaymentLink], error)) *PaymentLinks_GetAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItems provides a mock function with given fields: paymentLinkId
+func (_m *PaymentLinks) GetItems(paymentLinkId string) (*models.RetrieveAll[models.PItemsData], error) {
+	ret := _m.Called(paymentLinkId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItems")
+	}
+
+	var r0 *models.RetrieveAll[models.PItemsData]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.RetrieveAll[models.PItemsData], error)); ok {
+		return rf(paymentLinkId)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.RetrieveAll[models.PItemsData]); ok {
+		r0 = rf(paymentLinkId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RetrieveAll[models.PItemsData])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(paymentLinkId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaymentLinks_GetItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItems'
+type PaymentLinks_GetItems_Call struct {
+	*mock.Call
+}
+
+// GetItems is a helper method to define mock.On call
+//   - paymentLinkId string
+func (_e *PaymentLinks_Expecter) GetItems(paymentLinkId interface{}) *PaymentLinks_GetItems_Call {
+	return &PaymentLinks_GetItems_Call{Call: _e.mock.On("GetItems", paymentLinkId)}
+}
+
+func (_c *PaymentLinks_GetItems_Call) Run(run func(paymentLinkId string)) *PaymentLinks_GetItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *PaymentLinks_GetItems_Call) Return(_a0 *models.RetrieveAll[models.PItemsData], _a1 error) *PaymentLinks_GetItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PaymentLinks_GetItems_Call) RunAndReturn(run func(string) (*models.RetrieveAll[models.PItemsData], error)) *PaymentLinks_GetItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: params
+func (_m *PaymentLinks) List(params ...*models.ListPaymentLinksParams) *chargily.Pager[models.PaymentLink] {
+	_va := make([]interface{}, len(params))
+	for _i := range params {
+		_va[_i] = params[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *chargily.Pager[models.PaymentLink]
+	if rf, ok := ret.Get(0).(func(...*models.ListPaymentLinksParams) *chargily.Pager[models.PaymentLink]); ok {
+		r0 = rf(params...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*chargily.Pager[models.PaymentLink])
+		}
+	}
+
+	return r0
+}
+
+// PaymentLinks_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type PaymentLinks_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - params ...*models.ListPaymentLinksParams
+func (_e *PaymentLinks_Expecter) List(params ...interface{}) *PaymentLinks_List_Call {
+	return &PaymentLinks_List_Call{Call: _e.mock.On("List",
+		append([]interface{}{}, params...)...)}
+}
+
+func (_c *PaymentLinks_List_Call) Run(run func(params ...*models.ListPaymentLinksParams)) *PaymentLinks_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]*models.ListPaymentLinksParams, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(*models.ListPaymentLinksParams)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *PaymentLinks_List_Call) Return(_a0 *chargily.Pager[models.PaymentLink]) *PaymentLinks_List_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *PaymentLinks_List_Call) RunAndReturn(run func(...*models.ListPaymentLinksParams) *chargily.Pager[models.PaymentLink]) *PaymentLinks_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: paymentLinkId, paymentLink
+func (_m *PaymentLinks) Update(paymentLinkId string, paymentLink *models.CreatePaymentLinkParams) (*models.PaymentLink, error) {
+	ret := _m.Called(paymentLinkId, paymentLink)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 *models.PaymentLink
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *models.CreatePaymentLinkParams) (*models.PaymentLink, error)); ok {
+		return rf(paymentLinkId, paymentLink)
+	}
+	if rf, ok := ret.Get(0).(func(string, *models.CreatePaymentLinkParams) *models.PaymentLink); ok {
+		r0 = rf(paymentLinkId, paymentLink)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PaymentLink)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *models.CreatePaymentLinkParams) error); ok {
+		r1 = rf(paymentLinkId, paymentLink)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PaymentLinks_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type PaymentLinks_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - paymentLinkId string
+//   - paymentLink *models.CreatePaymentLinkParams
+func (_e *PaymentLinks_Expecter) Update(paymentLinkId interface{}, paymentLink interface{}) *PaymentLinks_Update_Call {
+	return &PaymentLinks_Update_Call{Call: _e.mock.On("Update", paymentLinkId, paymentLink)}
+}
+
+func (_c *PaymentLinks_Update_Call) Run(run func(paymentLinkId string, paymentLink *models.CreatePaymentLinkParams)) *PaymentLinks_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*models.CreatePaymentLinkParams))
+	})
+	return _c
+}
+
+func (_c *PaymentLinks_Update_Call) Return(_a0 *models.PaymentLink, _a1 error) *PaymentLinks_Update_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *PaymentLinks_Update_Call) RunAndReturn(run func(string, *models.CreatePaymentLinkParams) (*models.PaymentLink, error)) *PaymentLinks_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewPaymentLinks creates a new instance of PaymentLinks. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentLinks(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentLinks {
+	mock := &PaymentLinks{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,36 @@
+// Package chargilytest helps testing the code using the Chargily client, the recorder subpackage
+// records the interactions with the API and replays them offline.
+package chargilytest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+)
+
+// CheckFixture fails the test for every field of a JSON fixture that the model doesn't have or
+// whose type doesn't fit the model, model is a value or a pointer of the model type
+// (e.g. models.RetrieveAll[models.Checkout]{}). It keeps the fixtures and the models in sync.
+func CheckFixture(t testing.TB, raw []byte, model any) {
+	t.Helper()
+
+	drifts, err := utils.CheckDrift(raw, model)
+	if err != nil {
+		t.Fatalf("invalid fixture: %v", err)
+	}
+	for _, drift := range drifts {
+		t.Errorf("fixture drifted from its model: %s", drift)
+	}
+}
+
+// CheckFixtureFile checks the JSON fixture file at path like CheckFixture.
+func CheckFixtureFile(t testing.TB, path string, model any) {
+	t.Helper()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	CheckFixture(t, raw, model)
+}
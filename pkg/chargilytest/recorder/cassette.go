@@ -0,0 +1,83 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Cassette holds the recorded interactions of a test, in the order they happened.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions" yaml:"interactions"`
+}
+
+// Interaction is a recorded request and the response the API gave to it.
+type Interaction struct {
+	Request  Request  `json:"request" yaml:"request"`
+	Response Response `json:"response" yaml:"response"`
+}
+
+// Request is a recorded request, its credentials are scrubbed.
+type Request struct {
+	Method  string      `json:"method" yaml:"method"`
+	URL     string      `json:"url" yaml:"url"`
+	Headers http.Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body    string      `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	Status     string      `json:"status" yaml:"status"`
+	StatusCode int         `json:"status_code" yaml:"status_code"`
+	Headers    http.Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body       string      `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// isYAML reports whether the cassette file is written as YAML rather than JSON
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// LoadCassette reads a cassette file, as YAML if its extension is .yaml or .yml and as JSON otherwise.
+func LoadCassette(path string) (*Cassette, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cassette Cassette
+	if isYAML(path) {
+		err = yaml.Unmarshal(raw, &cassette)
+	} else {
+		err = json.Unmarshal(raw, &cassette)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %v", path, err)
+	}
+	return &cassette, nil
+}
+
+// Save writes the cassette file, as YAML if its extension is .yaml or .yml and as JSON otherwise.
+func (c *Cassette) Save(path string) error {
+	var raw []byte
+	var err error
+	if isYAML(path) {
+		raw, err = yaml.Marshal(c)
+	} else {
+		raw, err = json.MarshalIndent(c, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
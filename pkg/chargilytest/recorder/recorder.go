@@ -0,0 +1,199 @@
+// Package recorder records the interactions of a client with the Chargily API into cassettes
+// and replays them offline, for deterministic tests that do not need network access.
+package recorder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+)
+
+// Mode tells whether a recorder talks to the API or replays a cassette.
+type Mode int
+
+// Recorder modes
+const (
+	// ModeAuto replays the cassette if it exists, and records a new one otherwise.
+	ModeAuto Mode = iota
+	// ModeRecord sends the requests to the API and records them, replacing the cassette.
+	ModeRecord
+	// ModeReplay answers the requests from the cassette without network access.
+	ModeReplay
+)
+
+// scrubbed replaces the credentials in the recorded interactions
+const scrubbed = "[SCRUBBED]"
+
+// ErrNoInteraction is returned when a replayed request was not recorded in the cassette.
+var ErrNoInteraction = errors.New("recorder: no recorded interaction matches the request")
+
+// ErrLiveMode is returned when a request to the production API is about to be recorded.
+var ErrLiveMode = errors.New("recorder: only the test mode API can be recorded")
+
+// Recorder records or replays the requests of a client, see Option.
+type Recorder struct {
+	path     string
+	mode     Mode
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool // replayed interactions
+}
+
+// New creates a recorder for the cassette file at path, written as YAML if its extension is
+// .yaml or .yml and as JSON otherwise.
+func New(path string, mode Mode) (*Recorder, error) {
+	if mode == ModeAuto {
+		mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			mode = ModeReplay
+		}
+	}
+
+	r := &Recorder{path: path, mode: mode, cassette: &Cassette{}}
+	if mode == ModeReplay {
+		cassette, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = cassette
+		r.used = make([]bool, len(cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Mode returns the mode the recorder runs in, ModeAuto is resolved when the recorder is created.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Option plugs the recorder into a client as a middleware, in the order of the options: it sees
+// the requests as changed by the middlewares given before it. The middlewares given after it and
+// the logging of chargily.WithLogger, which the client always puts last, sit between the recorder
+// and the network, so they don't see the replayed requests.
+func (r *Recorder) Option() chargily.ClientOption {
+	return chargily.WithMiddleware(r.Middleware)
+}
+
+// Middleware records the requests going to next, or answers them from the cassette.
+func (r *Recorder) Middleware(next http.RoundTripper) http.RoundTripper {
+	return chargily.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		request, err := newRequest(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.mode == ModeReplay {
+			return r.replay(req, request)
+		}
+		return r.record(next, req, request)
+	})
+}
+
+// Stop writes the cassette when recording, it does nothing when replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// record sends the request to the API and appends the interaction to the cassette
+func (r *Recorder) record(next http.RoundTripper, req *http.Request, request Request) (*http.Response, error) {
+	if strings.HasPrefix(req.URL.String(), chargily.ProdAPIBaseUrl) {
+		return nil, ErrLiveMode
+	}
+
+	res, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: request,
+		Response: Response{
+			Status:     res.Status,
+			StatusCode: res.StatusCode,
+			Headers:    res.Header.Clone(),
+			Body:       string(body),
+		},
+	})
+	r.mu.Unlock()
+	return res, nil
+}
+
+// replay answers the request with the first unused interaction matching it
+func (r *Recorder) replay(req *http.Request, request Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !interaction.Request.matches(request) {
+			continue
+		}
+		r.used[i] = true
+
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        recorded.Status,
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, request.Method, request.URL)
+}
+
+// newRequest copies the request for the cassette, without its credentials. The body of the
+// request is read and restored.
+func newRequest(req *http.Request) (Request, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return Request{}, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	headers := req.Header.Clone()
+	apiKey := strings.TrimPrefix(headers.Get("Authorization"), "Bearer ")
+	if headers.Get("Authorization") != "" {
+		headers.Set("Authorization", "Bearer "+scrubbed)
+	}
+
+	text := string(body)
+	if apiKey != "" {
+		text = strings.ReplaceAll(text, apiKey, scrubbed)
+	}
+	return Request{Method: req.Method, URL: req.URL.String(), Headers: headers, Body: text}, nil
+}
+
+// matches reports whether a recorded request is the same as the replayed one
+func (r Request) matches(other Request) bool {
+	return r.Method == other.Method && r.URL == other.URL && r.Body == other.Body
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargilytest/recorder"
+)
+
+// TestCustomersExamples runs the customers examples against the recorded API. Delete the cassette
+// and set CHARGILY_TEST_API_KEY to record it again against the test mode API.
+func TestCustomersExamples(t *testing.T) {
+	rec, err := recorder.New("testdata/cassettes/customers.yaml", recorder.ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := rec.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// the API key is only sent when recording
+	apiKey := os.Getenv("CHARGILY_TEST_API_KEY")
+	if rec.Mode() == recorder.ModeReplay {
+		apiKey = "replayed"
+	}
+	client, err := chargily.NewClient(apiKey, "test", rec.Option())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() { CreateCustomer(client) })
+	expectOutput(t, out, "Customer created successfully")
+	match := regexp.MustCompile(`\{ID:(\S+)`).FindStringSubmatch(out)
+	if match == nil {
+		t.Fatalf("no customer ID in the output: %s", out)
+	}
+	customerID := match[1]
+
+	expectOutput(t, captureOutput(t, func() { GetCustomer(customerID, client) }), "Customer retrieved successfully")
+	expectOutput(t, captureOutput(t, func() { UpdateCustomer(customerID, client) }), "john.updated.doe@example.com")
+	expectOutput(t, captureOutput(t, func() { GetAllCustomers(client) }), "All customers retrieved successfully")
+	expectOutput(t, captureOutput(t, func() { DeleteCustomer(customerID, client) }), "Customer deleted successfully.")
+}
+
+// expectOutput fails the test when the output of an example is an error or misses the expected text
+func expectOutput(t *testing.T, out, expected string) {
+	t.Helper()
+	if strings.Contains(out, "Error") || !strings.Contains(out, expected) {
+		t.Errorf("expected %q in the output, got: %s", expected, out)
+	}
+}
+
+// captureOutput returns what fn prints on the standard output
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
@@ -25,7 +25,7 @@ func CreatePaymentLink(client *chargily.Client, id string) {
 		AfterCompletionMessage: "message",
 		Locale:                 "en",
 		PassFeesToCustomer:     false,
-		CollectShippingAddress: 1,
+		CollectShippingAddress: true,
 		Metadata: map[string]any{
 			"order_id": "order_54321",
 			"notes":    "This is a test order for payment link.",
@@ -22,7 +22,7 @@ func UpdatePaymentLink(client *chargily.Client, paymentLinkID string) {
 		AfterCompletionMessage: "Thank you for your updated order!",
 		Locale:                 "en",
 		PassFeesToCustomer:     false,
-		CollectShippingAddress: 0,
+		CollectShippingAddress: false,
 		Metadata: map[string]any{
 			"order_id": "updated_order_54321",
 			"notes":    "This is an updated test order for payment link.",
@@ -0,0 +1,170 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// table describes how a resource is flattened into CSV columns
+type table[T any] struct {
+	columns  []string
+	row      func(T) []string // values following the columns order
+	metadata func(T) map[string]any
+}
+
+// addressColumns are the flattened columns of a models.Address
+var addressColumns = []string{"address.address", "address.city", "address.state", "address.zip_code", "address.country"}
+
+var customerTable = table[models.Customer]{
+	columns: concat(
+		[]string{"id", "livemode", "name", "email", "phone"},
+		addressColumns,
+		[]string{"metadata", "created_at", "updated_at"},
+	),
+	row: func(c models.Customer) []string {
+		return concat(
+			[]string{c.ID, formatBool(c.Livemode), c.Name, c.Email, c.Phone},
+			addressValues(c.Address),
+			[]string{formatMetadata(c.Metadata), formatTime(c.CreatedAt), formatTime(c.UpdatedAt)},
+		)
+	},
+	metadata: func(c models.Customer) map[string]any { return c.Metadata },
+}
+
+var productTable = table[models.Product]{
+	columns: []string{"id", "livemode", "name", "description", "images", "metadata", "created_at", "updated_at"},
+	row: func(p models.Product) []string {
+		return []string{
+			p.ID, formatBool(p.Livemode), p.Name, p.Description, strings.Join(p.Images, "|"),
+			formatMetadata(p.Metadata), formatTime(p.CreatedAt), formatTime(p.UpdatedAt),
+		}
+	},
+	metadata: func(p models.Product) map[string]any { return p.Metadata },
+}
+
+var priceTable = table[models.ProductPrice]{
+	columns: []string{"id", "livemode", "product_id", "amount", "currency", "metadata", "created_at", "updated_at"},
+	row: func(p models.ProductPrice) []string {
+		return []string{
+			p.ID, formatBool(p.Livemode), p.ProductID, strconv.FormatInt(p.Amount, 10), p.Currency,
+			formatMetadata(p.Metadata), formatTime(p.CreatedAt), formatTime(p.UpdatedAt),
+		}
+	},
+	metadata: func(p models.ProductPrice) map[string]any { return p.Metadata },
+}
+
+var checkoutTable = table[models.Checkout]{
+	columns: []string{
+		"id", "livemode", "status", "amount", "amount_without_discount", "currency",
+		"fees", "fees_on_merchant", "fees_on_customer", "pass_fees_to_customer", "chargily_pay_fees_allocation",
+		"discount.type", "discount.value", "payment_method", "customer_id", "payment_link_id", "invoice_id",
+		"locale", "description", "shipping_address", "collect_shipping_address",
+		"success_url", "failure_url", "webhook_endpoint", "checkout_url",
+		"metadata", "created_at", "updated_at",
+	},
+	row: func(c models.Checkout) []string {
+		return []string{
+			c.ID, formatBool(c.Livemode), c.Status, strconv.FormatInt(c.Amount, 10), strconv.FormatInt(c.AmountWithoutDiscount, 10), c.Currency,
+			strconv.FormatInt(c.Fees, 10), strconv.FormatInt(c.FeesOnMerchant, 10), strconv.FormatInt(c.FeesOnCustomer, 10),
+			formatNullableBool(c.PassFeesToCustomer), c.ChargilyPayFeesAllocation,
+			c.Discount.Type, strconv.Itoa(c.Discount.Value), formatNullable(c.PaymentMethod), c.CustomerID,
+			formatNullable(c.PaymentLinkID), formatNullable(c.InvoiceID),
+			c.Locale, formatNullable(c.Description), formatNullable(c.ShippingAddress), formatBool(bool(c.CollectShippingAddress)),
+			c.SuccessURL, c.FailureURL, formatNullable(c.WebhookEndpoint), c.CheckoutURL,
+			formatMetadata(c.Metadata), formatTime(c.CreatedAt), formatTime(c.UpdatedAt),
+		}
+	},
+	metadata: func(c models.Checkout) map[string]any { return c.Metadata },
+}
+
+var paymentLinkTable = table[models.PaymentLink]{
+	columns: []string{
+		"id", "livemode", "name", "active", "locale", "pass_fees_to_customer", "collect_shipping_address",
+		"after_completion_message", "url", "metadata", "created_at", "updated_at",
+	},
+	row: func(p models.PaymentLink) []string {
+		return []string{
+			p.ID, formatBool(p.Livemode), p.Name, formatBool(bool(p.Active)), p.Locale, formatBool(p.PassFeesToCustomer),
+			formatBool(bool(p.CollectShippingAddress)), p.AfterCompletionMessage, p.URL,
+			formatMetadata(p.Metadata), formatTime(p.CreatedAt), formatTime(p.UpdatedAt),
+		}
+	},
+	metadata: func(p models.PaymentLink) map[string]any { return p.Metadata },
+}
+
+// addressValues flattens an address following the addressColumns order
+func addressValues(a *models.Address) []string {
+	if a == nil {
+		return make([]string, len(addressColumns))
+	}
+	return []string{a.Address, a.City, a.State, a.ZipCode, a.Country}
+}
+
+// metadataValue formats a single metadata entry, strings are written as is and other values as JSON
+func metadataValue(metadata map[string]any, key string) string {
+	value, ok := metadata[key]
+	if !ok || value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// formatMetadata encodes the whole metadata as JSON, empty metadata gives an empty cell
+func formatMetadata(metadata map[string]any) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+	// json.Marshal sorts the map keys, which keeps the output stable
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// formatTime formats a unix timestamp as RFC 3339 in UTC
+func formatTime(t models.Timestamp) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatBool(b bool) string {
+	return strconv.FormatBool(b)
+}
+
+func formatNullableBool(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func formatNullable(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// concat joins the column groups into a single slice
+func concat(groups ...[]string) []string {
+	var result []string
+	for _, g := range groups {
+		result = append(result, g...)
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// Format is the output format of an export.
+type Format string
+
+// Supported export formats
+const (
+	CSV   Format = "csv"   // One header row followed by one flattened row per record.
+	JSONL Format = "jsonl" // One JSON object per line, as returned by the API.
+)
+
+// Option customizes an export.
+type Option func(*options)
+
+type options struct {
+	since        time.Time
+	until        time.Time
+	metadataKeys []string
+}
+
+// CreatedAfter keeps only the records created at or after t.
+func CreatedAfter(t time.Time) Option {
+	return func(o *options) { o.since = t }
+}
+
+// CreatedBefore keeps only the records created strictly before t.
+func CreatedBefore(t time.Time) Option {
+	return func(o *options) { o.until = t }
+}
+
+// MetadataKeys adds one CSV column per given metadata key ("metadata.<key>") next to
+// the JSON encoded "metadata" column.
+func MetadataKeys(keys ...string) Option {
+	return func(o *options) { o.metadataKeys = append(o.metadataKeys, keys...) }
+}
+
+// Customers streams every customer of the account to w.
+func Customers(ctx context.Context, client *chargily.Client, w io.Writer, format Format, opts ...Option) error {
+	return run(ctx, func(created models.CreatedRange) *chargily.Pager[models.Customer] {
+		return client.WithContext(ctx).Customers.List(&models.ListCustomersParams{CreatedRange: created})
+	}, customerTable, w, format, opts)
+}
+
+// Products streams every product of the account to w.
+func Products(ctx context.Context, client *chargily.Client, w io.Writer, format Format, opts ...Option) error {
+	return run(ctx, func(created models.CreatedRange) *chargily.Pager[models.Product] {
+		return client.WithContext(ctx).Products.List(&models.ListProductsParams{CreatedRange: created})
+	}, productTable, w, format, opts)
+}
+
+// Prices streams every price of the account to w.
+func Prices(ctx context.Context, client *chargily.Client, w io.Writer, format Format, opts ...Option) error {
+	return run(ctx, func(created models.CreatedRange) *chargily.Pager[models.ProductPrice] {
+		return client.WithContext(ctx).Prices.List(&models.ListPricesParams{CreatedRange: created})
+	}, priceTable, w, format, opts)
+}
+
+// Checkouts streams every checkout of the account to w.
+func Checkouts(ctx context.Context, client *chargily.Client, w io.Writer, format Format, opts ...Option) error {
+	return run(ctx, func(created models.CreatedRange) *chargily.Pager[models.Checkout] {
+		return client.WithContext(ctx).Checkouts.List(&models.ListCheckoutsParams{CreatedRange: created})
+	}, checkoutTable, w, format, opts)
+}
+
+// PaymentLinks streams every payment link of the account to w.
+func PaymentLinks(ctx context.Context, client *chargily.Client, w io.Writer, format Format, opts ...Option) error {
+	return run(ctx, func(created models.CreatedRange) *chargily.Pager[models.PaymentLink] {
+		return client.WithContext(ctx).PaymentLinks.List(&models.ListPaymentLinksParams{CreatedRange: created})
+	}, paymentLinkTable, w, format, opts)
+}
+
+// run fetches the pages of a resource created in the range of the options and writes every record
+func run[T any](ctx context.Context, list func(models.CreatedRange) *chargily.Pager[T], t table[T], w io.Writer, format Format, opts []Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	write, flush, err := newWriter(w, format, t, o.metadataKeys)
+	if err != nil {
+		return err
+	}
+
+	err = list(models.CreatedRange{CreatedAfter: o.since, CreatedBefore: o.until}).ForEach(func(record T) error {
+		// stop walking through the pages once the context is done
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return write(record)
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+// newWriter returns the functions writing a record and flushing the output in the given format
+func newWriter[T any](w io.Writer, format Format, t table[T], metadataKeys []string) (func(T) error, func() error, error) {
+	switch format {
+	case JSONL:
+		enc := json.NewEncoder(w)
+		write := func(record T) error { return enc.Encode(record) }
+		return write, func() error { return nil }, nil
+
+	case CSV:
+		cw := csv.NewWriter(w)
+		header := append([]string{}, t.columns...)
+		for _, key := range metadataKeys {
+			header = append(header, "metadata."+key)
+		}
+		if err := cw.Write(header); err != nil {
+			return nil, nil, err
+		}
+
+		write := func(record T) error {
+			row := t.row(record)
+			metadata := t.metadata(record)
+			for _, key := range metadataKeys {
+				row = append(row, metadataValue(metadata, key))
+			}
+			return cw.Write(row)
+		}
+		flush := func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+		return write, flush, nil
+	}
+
+	return nil, nil, fmt.Errorf("unsupported export format: %q", format)
+}
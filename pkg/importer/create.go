@@ -0,0 +1,90 @@
+package importer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// createCustomer creates the customer of a row
+func (im *Importer) createCustomer(ctx context.Context, params models.CreateCustomerParams, key string, _ *Result) Result {
+	if err := im.wait(ctx); err != nil {
+		return failed(Result{}, err)
+	}
+
+	customer, err := im.client.Customers.Create(&params, chargily.WithContext(ctx), chargily.WithIdempotencyKey(key))
+	if err != nil {
+		return failed(Result{}, err)
+	}
+	return Result{Status: StatusCreated, ID: customer.ID}
+}
+
+// createProduct creates the product of a row then its prices, the product and the prices
+// created by a previous run are not created again. The key of the n-th price is key-price-n.
+func (im *Importer) createProduct(ctx context.Context, params productRow, key string, previous *Result) Result {
+	var res Result
+	if previous != nil {
+		res.ID = previous.ID
+		res.PriceIDs = append(res.PriceIDs, previous.PriceIDs...)
+	}
+
+	if res.ID == "" {
+		if err := im.wait(ctx); err != nil {
+			return failed(res, err)
+		}
+
+		product, err := im.client.Products.Create(&params.CreateProductParams, chargily.WithContext(ctx), chargily.WithIdempotencyKey(key))
+		if err != nil {
+			return failed(res, err)
+		}
+		res.ID = product.ID
+	}
+
+	for i := len(res.PriceIDs); i < len(params.Prices); i++ {
+		if err := im.wait(ctx); err != nil {
+			return failed(res, err)
+		}
+
+		price := params.Prices[i]
+		price.ProductID = res.ID
+		created, err := im.client.Prices.Create(&price, chargily.WithContext(ctx),
+			chargily.WithIdempotencyKey(key+"-price-"+strconv.Itoa(i+1)))
+		if err != nil {
+			return failed(res, err)
+		}
+		res.PriceIDs = append(res.PriceIDs, created.ID)
+	}
+
+	res.Status = StatusCreated
+	return res
+}
+
+// idempotencyKey derives the idempotency key of a row from the source of the input, the row number
+// and its content, so the same row of the same input always gets the same key
+func (im *Importer) idempotencyKey(kind string, row int, params any) string {
+	content, _ := json.Marshal(params)
+	hash := sha256.New()
+	hash.Write([]byte(im.source + "\x00" + kind + "\x00" + strconv.Itoa(row) + "\x00"))
+	hash.Write(content)
+	return "import-" + hex.EncodeToString(hash.Sum(nil))[:32]
+}
+
+// failed marks the result as failed with the given error
+func failed(res Result, err error) Result {
+	res.Status = StatusFailed
+	res.Error = err.Error()
+	return res
+}
+
+// wait blocks until the rate limit of the importer, if any, allows the next request
+func (im *Importer) wait(ctx context.Context) error {
+	if im.limiter == nil {
+		return ctx.Err()
+	}
+	return im.limiter.Wait(ctx)
+}
@@ -0,0 +1,200 @@
+package importer
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+)
+
+// Format is the format of an import input.
+type Format string
+
+// Supported input formats
+const (
+	CSV   Format = "csv"   // A header row followed by one row per record, using the columns of the export package.
+	JSONL Format = "jsonl" // One JSON object per line.
+)
+
+// Importer creates records in bulk from CSV or JSON Lines inputs.
+type Importer struct {
+	client  *chargily.Client
+	workers int
+	limiter *utils.RateLimiter
+	done    map[int]Result
+	source  string
+}
+
+// Option customizes an Importer.
+type Option func(*Importer)
+
+// Workers sets the number of records created concurrently (defaults to 4).
+func Workers(n int) Option {
+	return func(im *Importer) {
+		if n > 0 {
+			im.workers = n
+		}
+	}
+}
+
+// RateLimit caps the number of API requests per second sent by the importer (unlimited by default).
+// Use chargily.WithRateLimit instead to share the limit with the other users of the client.
+func RateLimit(rps float64) Option {
+	return func(im *Importer) {
+		if rps > 0 {
+			im.limiter = utils.NewRateLimiter(rps, 1)
+		}
+	}
+}
+
+// Resume skips the rows already created by a previous run, as loaded by LoadResults.
+// Products created without all of their prices only get their remaining prices created.
+func Resume(previous map[int]Result) Option {
+	return func(im *Importer) { im.done = previous }
+}
+
+// Source names the input (e.g. its path) in the idempotency keys of the create requests, which
+// are derived from the input and the row. A run resumed after a crash or a timeout reuses the
+// keys of the interrupted one, so the API doesn't create the records it already created again.
+func Source(name string) Option {
+	return func(im *Importer) { im.source = name }
+}
+
+// New creates an importer sending its requests through the given client.
+func New(client *chargily.Client, opts ...Option) *Importer {
+	im := &Importer{
+		client:  client,
+		workers: 4,
+	}
+	for _, opt := range opts {
+		opt(im)
+	}
+	return im
+}
+
+// Summary counts the outcome of an import.
+type Summary struct {
+	Total   int // Rows read from the input.
+	Created int // Rows created during this run.
+	Skipped int // Rows already created by a previous run.
+	Failed  int // Rows rejected by the API.
+	Invalid int // Rows that did not pass the validation.
+}
+
+// Customers creates one customer per input row and writes the outcome of every row to results.
+func (im *Importer) Customers(ctx context.Context, r io.Reader, format Format, results io.Writer) (*Summary, error) {
+	return run(ctx, im, r, format, results, customerKind, im.createCustomer)
+}
+
+// Products creates one product per input row followed by its prices, and writes the outcome of
+// every row to results.
+func (im *Importer) Products(ctx context.Context, r io.Reader, format Format, results io.Writer) (*Summary, error) {
+	return run(ctx, im, r, format, results, productKind, im.createProduct)
+}
+
+// task is a validated input row waiting to be created
+type task[T any] struct {
+	row      int
+	params   T
+	key      string  // idempotency key of the create requests of the row
+	previous *Result // outcome of a previous run, if any
+}
+
+// run reads the input rows and creates them with a bounded pool of workers,
+// the outcome of every row is written to results as soon as it is known
+func run[T any](ctx context.Context, im *Importer, r io.Reader, format Format, results io.Writer, k kind[T], create func(context.Context, T, string, *Result) Result) (*Summary, error) {
+	rw, err := newResultWriter(results)
+	if err != nil {
+		return nil, err
+	}
+
+	// the rows created by a previous run are carried over, so the new results file is complete
+	for _, res := range sortedResults(im.done) {
+		if res.Status == StatusCreated {
+			if err := rw.write(res); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var summary Summary
+	outcomes := make(chan Result)
+	written := make(chan error, 1)
+
+	// a single goroutine writes the results, so the output is never interleaved
+	go func() {
+		var err error
+		for res := range outcomes {
+			summary.count(res.Status)
+			if err == nil {
+				err = rw.write(res)
+			}
+		}
+		written <- err
+	}()
+
+	tasks := make(chan task[T])
+	var wg sync.WaitGroup
+	for i := 0; i < im.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for t := range tasks {
+				res := create(ctx, t.params, t.key, t.previous)
+				res.Row = t.row
+				outcomes <- res
+			}
+		}()
+	}
+
+	var total, skipped int
+	readErr := readRows(r, format, k, func(row int, params T, err error) error {
+		total++
+		if err != nil {
+			outcomes <- Result{Row: row, Status: StatusInvalid, Error: err.Error()}
+			return nil
+		}
+
+		var previous *Result
+		if res, ok := im.done[row]; ok {
+			if res.Status == StatusCreated {
+				skipped++
+				return nil
+			}
+			previous = &res
+		}
+
+		select {
+		case tasks <- task[T]{row: row, params: params, key: im.idempotencyKey(k.name, row, params), previous: previous}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+
+	close(tasks)
+	wg.Wait()
+	close(outcomes)
+	writeErr := <-written
+
+	summary.Total = total
+	summary.Skipped = skipped
+	if readErr != nil {
+		return &summary, readErr
+	}
+	return &summary, writeErr
+}
+
+// count accounts a row outcome in the summary
+func (s *Summary) count(status Status) {
+	switch status {
+	case StatusCreated:
+		s.Created++
+	case StatusFailed:
+		s.Failed++
+	case StatusInvalid:
+		s.Invalid++
+	}
+}
@@ -0,0 +1,109 @@
+package importer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Status is the outcome of an input row.
+type Status string
+
+// Possible row outcomes
+const (
+	StatusCreated Status = "created" // The record (and its prices) was created.
+	StatusFailed  Status = "failed"  // The API rejected the record, or a request failed.
+	StatusInvalid Status = "invalid" // The row did not pass the validation and was not sent.
+)
+
+// Result maps an input row to the created record or to the error it ran into.
+type Result struct {
+	Row      int      // The 1-based number of the row in the input, headers excluded.
+	Status   Status   // The outcome of the row.
+	ID       string   // The ID of the created customer or product.
+	PriceIDs []string // The IDs of the created prices, for products.
+	Error    string   // The error message of failed and invalid rows.
+}
+
+// resultsHeader is the header of a results file
+var resultsHeader = []string{"row", "status", "id", "price_ids", "error"}
+
+// LoadResults reads the results file of a previous run, to be passed to Resume.
+func LoadResults(r io.Reader) (map[int]Result, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return map[int]Result{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if strings.Join(header, ",") != strings.Join(resultsHeader, ",") {
+		return nil, fmt.Errorf("not a results file, unexpected header: %v", header)
+	}
+
+	results := map[int]Result{}
+	for {
+		values, err := cr.Read()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row, err := strconv.Atoi(values[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid row number %q", values[0])
+		}
+
+		res := Result{Row: row, Status: Status(values[1]), ID: values[2], Error: values[4]}
+		if values[3] != "" {
+			res.PriceIDs = strings.Split(values[3], "|")
+		}
+		results[row] = res
+	}
+}
+
+// resultWriter writes the results as CSV, flushing every row so an interrupted
+// run still leaves a usable checkpoint
+type resultWriter struct {
+	cw *csv.Writer
+}
+
+func newResultWriter(w io.Writer) (*resultWriter, error) {
+	rw := &resultWriter{cw: csv.NewWriter(w)}
+	if err := rw.cw.Write(resultsHeader); err != nil {
+		return nil, err
+	}
+	rw.cw.Flush()
+	return rw, rw.cw.Error()
+}
+
+func (rw *resultWriter) write(res Result) error {
+	err := rw.cw.Write([]string{
+		strconv.Itoa(res.Row),
+		string(res.Status),
+		res.ID,
+		strings.Join(res.PriceIDs, "|"),
+		res.Error,
+	})
+	if err != nil {
+		return err
+	}
+	rw.cw.Flush()
+	return rw.cw.Error()
+}
+
+// sortedResults returns the results ordered by row number
+func sortedResults(results map[int]Result) []Result {
+	sorted := make([]Result, 0, len(results))
+	for _, res := range results {
+		sorted = append(sorted, res)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Row < sorted[j].Row })
+	return sorted
+}
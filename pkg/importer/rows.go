@@ -0,0 +1,207 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// maxImages is the number of images a product can have
+const maxImages = 8
+
+// currencies accepted by the Chargily API
+var currencies = map[string]bool{"dzd": true, "usd": true, "eur": true}
+
+// productRow is a product with the prices to create for it
+type productRow struct {
+	models.CreateProductParams
+	Prices []models.ProductPriceParams `json:"prices,omitempty"`
+}
+
+// kind describes how the rows of a resource are parsed and validated
+type kind[T any] struct {
+	name     string
+	fromCSV  func(record map[string]string) (T, error)
+	validate func(*T) error
+}
+
+var customerKind = kind[models.CreateCustomerParams]{
+	name: "customers",
+	fromCSV: func(record map[string]string) (models.CreateCustomerParams, error) {
+		params := models.CreateCustomerParams{
+			Name:  record["name"],
+			Email: record["email"],
+			Phone: record["phone"],
+		}
+
+		address := models.Address{
+			Address: record["address.address"],
+			City:    record["address.city"],
+			State:   record["address.state"],
+			ZipCode: record["address.zip_code"],
+			Country: record["address.country"],
+		}
+		if address != (models.Address{}) {
+			params.Address = &address
+		}
+
+		metadata, err := parseMetadata(record)
+		params.Metadata = metadata
+		return params, err
+	},
+	validate: func(params *models.CreateCustomerParams) error {
+		if params.Name == "" {
+			return errors.New("name is required")
+		}
+		if params.Email != "" {
+			if _, err := mail.ParseAddress(params.Email); err != nil {
+				return fmt.Errorf("invalid email %q", params.Email)
+			}
+		}
+		return params.Metadata.Validate()
+	},
+}
+
+var productKind = kind[productRow]{
+	name: "products",
+	fromCSV: func(record map[string]string) (productRow, error) {
+		var params productRow
+		params.Name = record["name"]
+		params.Description = record["description"]
+		if images := record["images"]; images != "" {
+			params.Images = strings.Split(images, "|")
+		}
+
+		metadata, err := parseMetadata(record)
+		if err != nil {
+			return params, err
+		}
+		params.Metadata = metadata
+
+		// a CSV row holds at most one price
+		if amount := record["price.amount"]; amount != "" {
+			value, err := strconv.ParseInt(amount, 10, 64)
+			if err != nil {
+				return params, fmt.Errorf("invalid price.amount %q", amount)
+			}
+			params.Prices = []models.ProductPriceParams{{Amount: value, Currency: record["price.currency"]}}
+		}
+		return params, nil
+	},
+	validate: func(params *productRow) error {
+		if params.Name == "" {
+			return errors.New("name is required")
+		}
+		if len(params.Images) > maxImages {
+			return fmt.Errorf("a product can have up to %d images, got %d", maxImages, len(params.Images))
+		}
+		for i, price := range params.Prices {
+			if price.Amount <= 0 {
+				return fmt.Errorf("price %d: amount must be positive", i+1)
+			}
+			if !currencies[strings.ToLower(price.Currency)] {
+				return fmt.Errorf("price %d: unsupported currency %q", i+1, price.Currency)
+			}
+		}
+		return params.Metadata.Validate()
+	},
+}
+
+// parseMetadata merges the JSON "metadata" column and the "metadata.<key>" columns of a record
+func parseMetadata(record map[string]string) (map[string]any, error) {
+	var metadata map[string]any
+	if raw := record["metadata"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+			return nil, fmt.Errorf("invalid metadata: %v", err)
+		}
+	}
+
+	for column, value := range record {
+		key, ok := strings.CutPrefix(column, "metadata.")
+		if !ok || value == "" {
+			continue
+		}
+		if metadata == nil {
+			metadata = map[string]any{}
+		}
+		metadata[key] = value
+	}
+	return metadata, nil
+}
+
+// readRows parses and validates every row of the input and calls fn with its 1-based number,
+// rows that cannot be parsed or validated are passed along with their error
+func readRows[T any](r io.Reader, format Format, k kind[T], fn func(row int, params T, err error) error) error {
+	parse := func(row int, params T, err error) error {
+		if err == nil {
+			err = k.validate(&params)
+		}
+		return fn(row, params, err)
+	}
+
+	switch format {
+	case CSV:
+		cr := csv.NewReader(r)
+		header, err := cr.Read()
+		if err != nil {
+			return fmt.Errorf("failed to read the CSV header: %v", err)
+		}
+
+		for row := 1; ; row++ {
+			values, err := cr.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read row %d: %v", row, err)
+			}
+
+			record := make(map[string]string, len(header))
+			for i, column := range header {
+				if i < len(values) {
+					record[strings.TrimSpace(column)] = strings.TrimSpace(values[i])
+				}
+			}
+
+			params, err := k.fromCSV(record)
+			if err := parse(row, params, err); err != nil {
+				return err
+			}
+		}
+
+	case JSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 1024*1024)
+		for row := 1; scanner.Scan(); row++ {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				row--
+				continue
+			}
+
+			var params T
+			dec := json.NewDecoder(bytes.NewReader(line))
+			// unknown fields are most likely typos, reject them rather than silently dropping data
+			dec.DisallowUnknownFields()
+			err := dec.Decode(&params)
+			if err != nil {
+				err = fmt.Errorf("invalid JSON: %v", err)
+			}
+			if err := parse(row, params, err); err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+	}
+
+	return fmt.Errorf("unsupported import format: %q", format)
+}
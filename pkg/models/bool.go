@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//******************************************************************//
+//============================ BOOL ================================//
+/////////////////////////////////////////////////////////////////////
+
+// Bool is a boolean field the API sends either as a JSON boolean or as 0 and 1 (e.g. active,
+// collect_shipping_address). It decodes from true, false, 0, 1, null and the same values in a
+// string ("1", "true"), and always encodes as a JSON boolean.
+type Bool bool
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
+
+func (b Bool) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatBool(bool(b))), nil
+}
@@ -0,0 +1,201 @@
+package models
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//******************************************************************//
+//========================= LIST PARAMS ============================//
+/////////////////////////////////////////////////////////////////////
+
+// ListFilter is implemented by the list params of a resource. The query is sent with the request,
+// and the objects of the returned page that don't Match are dropped, so the criteria the API
+// ignores are still applied on the client side.
+type ListFilter[T any] interface {
+	Query() url.Values
+	Match(T) bool
+}
+
+// ListParams selects the page of a list, it is sent in the query string of the request.
+type ListParams struct {
+	Page    int // The page to retrieve, starting from 1. The first page if zero.
+	PerPage int // The number of objects per page. The API default if zero.
+}
+
+// Query encodes the parameters set as query parameters.
+func (p *ListParams) Query() url.Values {
+	query := url.Values{}
+	if p == nil {
+		return query
+	}
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(p.PerPage))
+	}
+	return query
+}
+
+// CreatedRange selects the objects created in a time range, the zero times are ignored.
+type CreatedRange struct {
+	CreatedAfter  time.Time // Only the objects created at or after this time.
+	CreatedBefore time.Time // Only the objects created before this time.
+}
+
+func (r CreatedRange) encode(query url.Values) {
+	if !r.CreatedAfter.IsZero() {
+		query.Set("created_after", strconv.FormatInt(r.CreatedAfter.Unix(), 10))
+	}
+	if !r.CreatedBefore.IsZero() {
+		query.Set("created_before", strconv.FormatInt(r.CreatedBefore.Unix(), 10))
+	}
+}
+
+func (r CreatedRange) match(createdAt Timestamp) bool {
+	created := createdAt.Time
+	if !r.CreatedAfter.IsZero() && created.Before(r.CreatedAfter) {
+		return false
+	}
+	return r.CreatedBefore.IsZero() || created.Before(r.CreatedBefore)
+}
+
+// setNonEmpty sets the query parameter if the value is not empty
+func setNonEmpty(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+// ListCustomersParams filters the customers returned by Customers.GetAll.
+type ListCustomersParams struct {
+	ListParams
+	CreatedRange
+	Email string // Only the customers with this email address, compared case insensitively.
+}
+
+func (p *ListCustomersParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+	query := p.ListParams.Query()
+	p.CreatedRange.encode(query)
+	setNonEmpty(query, "email", p.Email)
+	return query
+}
+
+func (p *ListCustomersParams) Match(customer Customer) bool {
+	if p == nil {
+		return true
+	}
+	return p.CreatedRange.match(customer.CreatedAt) &&
+		(p.Email == "" || strings.EqualFold(p.Email, customer.Email))
+}
+
+// ListProductsParams filters the products returned by Products.GetAll.
+type ListProductsParams struct {
+	ListParams
+	CreatedRange
+}
+
+func (p *ListProductsParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+	query := p.ListParams.Query()
+	p.CreatedRange.encode(query)
+	return query
+}
+
+func (p *ListProductsParams) Match(product Product) bool {
+	return p == nil || p.CreatedRange.match(product.CreatedAt)
+}
+
+// ListPricesParams filters the prices returned by Prices.GetAll.
+type ListPricesParams struct {
+	ListParams
+	CreatedRange
+	ProductID string // Only the prices of this product.
+	Currency  string // Only the prices in this currency (e.g., "dzd").
+}
+
+func (p *ListPricesParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+	query := p.ListParams.Query()
+	p.CreatedRange.encode(query)
+	setNonEmpty(query, "product_id", p.ProductID)
+	setNonEmpty(query, "currency", p.Currency)
+	return query
+}
+
+func (p *ListPricesParams) Match(price ProductPrice) bool {
+	if p == nil {
+		return true
+	}
+	return p.CreatedRange.match(price.CreatedAt) &&
+		(p.ProductID == "" || p.ProductID == price.ProductID) &&
+		(p.Currency == "" || strings.EqualFold(p.Currency, price.Currency))
+}
+
+// ListCheckoutsParams filters the checkouts returned by Checkouts.GetAll.
+type ListCheckoutsParams struct {
+	ListParams
+	CreatedRange
+	Status        string // Only the checkouts with this status (e.g., "paid", "pending").
+	CustomerID    string // Only the checkouts of this customer.
+	PaymentLinkID string // Only the checkouts created from this payment link.
+}
+
+func (p *ListCheckoutsParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+	query := p.ListParams.Query()
+	p.CreatedRange.encode(query)
+	setNonEmpty(query, "status", p.Status)
+	setNonEmpty(query, "customer_id", p.CustomerID)
+	setNonEmpty(query, "payment_link_id", p.PaymentLinkID)
+	return query
+}
+
+func (p *ListCheckoutsParams) Match(checkout Checkout) bool {
+	if p == nil {
+		return true
+	}
+	return p.CreatedRange.match(checkout.CreatedAt) &&
+		(p.Status == "" || p.Status == checkout.Status) &&
+		(p.CustomerID == "" || p.CustomerID == checkout.CustomerID) &&
+		(p.PaymentLinkID == "" || (checkout.PaymentLinkID != nil && p.PaymentLinkID == *checkout.PaymentLinkID))
+}
+
+// ListPaymentLinksParams filters the payment links returned by PaymentLinks.GetAll.
+type ListPaymentLinksParams struct {
+	ListParams
+	CreatedRange
+	Active *bool // Only the active or the inactive payment links, both if nil.
+}
+
+func (p *ListPaymentLinksParams) Query() url.Values {
+	if p == nil {
+		return url.Values{}
+	}
+	query := p.ListParams.Query()
+	p.CreatedRange.encode(query)
+	if p.Active != nil {
+		query.Set("active", strconv.FormatBool(*p.Active))
+	}
+	return query
+}
+
+func (p *ListPaymentLinksParams) Match(paymentLink PaymentLink) bool {
+	if p == nil {
+		return true
+	}
+	return p.CreatedRange.match(paymentLink.CreatedAt) &&
+		(p.Active == nil || *p.Active == bool(paymentLink.Active))
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+//******************************************************************//
+//=========================== METADATA =============================//
+/////////////////////////////////////////////////////////////////////
+
+// Limits checked by Metadata.Validate and SetMetadata before the metadata is sent
+const (
+	MaxMetadataKeys        = 50  // The maximum number of keys.
+	MaxMetadataKeyLength   = 40  // The maximum length of a key, in bytes.
+	MaxMetadataValueLength = 500 // The maximum length of a JSON encoded value, in bytes.
+)
+
+// ErrInvalidMetadata is returned when the metadata exceeds the limits or can't be decoded
+var ErrInvalidMetadata = errors.New("invalid metadata")
+
+// Metadata holds the key-value pairs attached to an object.
+// It decodes from an object, and from the forms the API uses for empty metadata (null, "" and
+// []) or for metadata sent as a JSON string (in the webhook events), to a nil map for empty metadata.
+type Metadata map[string]any
+
+func (m *Metadata) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*m = nil
+		return nil
+
+	case len(data) > 0 && data[0] == '"':
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		if text == "" {
+			*m = nil
+			return nil
+		}
+		return m.UnmarshalJSON([]byte(text))
+
+	case len(data) > 0 && data[0] == '[':
+		var list []any
+		if err := json.Unmarshal(data, &list); err != nil || len(list) > 0 {
+			return fmt.Errorf("%w: expected an object, got %s", ErrInvalidMetadata, data)
+		}
+		*m = nil
+		return nil
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
+	}
+	*m = object
+	return nil
+}
+
+// Validate checks the number of keys and the length of the keys and values of the metadata.
+func (m Metadata) Validate() error {
+	if len(m) > MaxMetadataKeys {
+		return fmt.Errorf("%w: %d keys, the limit is %d", ErrInvalidMetadata, len(m), MaxMetadataKeys)
+	}
+	for key, value := range m {
+		if key == "" || len(key) > MaxMetadataKeyLength {
+			return fmt.Errorf("%w: key %q must have 1 to %d bytes", ErrInvalidMetadata, key, MaxMetadataKeyLength)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("%w: key %q: %v", ErrInvalidMetadata, key, err)
+		}
+		if len(encoded) > MaxMetadataValueLength {
+			return fmt.Errorf("%w: value of key %q has %d bytes, the limit is %d", ErrInvalidMetadata, key, len(encoded), MaxMetadataValueLength)
+		}
+	}
+	return nil
+}
+
+// MetadataCarrier is implemented by the params and the objects holding metadata, see SetMetadata
+// and GetMetadata.
+type MetadataCarrier interface {
+	metadata() *Metadata
+}
+
+// SetMetadata encodes v, a struct or a map, into the metadata of the params: every JSON field of v
+// becomes a metadata key, the other keys of the metadata are kept. Nothing is changed when the
+// resulting metadata exceeds the limits.
+//
+//	type OrderMeta struct {
+//	    OrderID  string `json:"order_id"`
+//	    TenantID string `json:"tenant_id"`
+//	}
+//
+//	params := &models.CheckoutParams{Amount: 2500, Currency: "dzd", SuccessURL: successURL}
+//	err := models.SetMetadata(params, OrderMeta{OrderID: "1042", TenantID: "acme"})
+func SetMetadata[T any](params MetadataCarrier, v T) error {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return fmt.Errorf("%w: %T is not encoded as a JSON object", ErrInvalidMetadata, v)
+	}
+
+	target := params.metadata()
+	merged := make(Metadata, len(*target)+len(fields))
+	for key, value := range *target {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	if err := merged.Validate(); err != nil {
+		return err
+	}
+	*target = merged
+	return nil
+}
+
+// GetMetadata decodes the metadata of an object, or of a webhook event, into a T. The keys the T
+// doesn't have are ignored, and empty metadata gives the zero T.
+//
+//	checkout, err := client.Checkouts.Get(checkoutId)
+//	...
+//	meta, err := models.GetMetadata[OrderMeta](checkout)
+func GetMetadata[T any](obj MetadataCarrier) (T, error) {
+	var v T
+	metadata := *obj.metadata()
+	if len(metadata) == 0 {
+		return v, nil
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return v, fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
+	}
+	if err := json.Unmarshal(encoded, &v); err != nil {
+		return v, fmt.Errorf("%w: %v", ErrInvalidMetadata, err)
+	}
+	return v, nil
+}
+
+// Get returns the value of a metadata key as a string, numbers and booleans are formatted.
+func (m Metadata) Get(key string) string {
+	switch value := m[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	}
+}
+
+func (m *Metadata) metadata() *Metadata { return m }
+
+func (p *CreateCustomerParams) metadata() *Metadata      { return &p.Metadata }
+func (p *CreateProductParams) metadata() *Metadata       { return &p.Metadata }
+func (p *ProductPriceParams) metadata() *Metadata        { return &p.Metadata }
+func (p *UpdatePriceMetaDataParams) metadata() *Metadata { return &p.Metadata }
+func (p *CheckoutParams) metadata() *Metadata            { return &p.Metadata }
+func (p *CreatePaymentLinkParams) metadata() *Metadata   { return &p.Metadata }
+
+func (c *Customer) metadata() *Metadata      { return &c.Metadata }
+func (p *Product) metadata() *Metadata       { return &p.Metadata }
+func (p *ProductPrice) metadata() *Metadata  { return &p.Metadata }
+func (c *Checkout) metadata() *Metadata      { return &c.Metadata }
+func (p *PaymentLink) metadata() *Metadata   { return &p.Metadata }
+func (i *CheckoutItems) metadata() *Metadata { return &i.Metadata }
+func (i *PItemsData) metadata() *Metadata    { return &i.Metadata }
+func (e *WebhookEvent) metadata() *Metadata  { return &e.Data.Metadata }
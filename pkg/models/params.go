@@ -10,7 +10,7 @@ type CreateCustomerParams struct {
 	Email    			string            					`json:"email,omitempty"`    // The email address of the customer.
 	Phone    			string            					`json:"phone,omitempty"`    // The phone number of the customer.
 	Address  			*Address          					`json:"address,omitempty"`  // The address of the customer.
-	Metadata 			map[string]any    					`json:"metadata,omitempty"`  // Additional info about the customer.
+	Metadata 			Metadata          					`json:"metadata,omitempty"`  // Additional info about the customer.
 }
 
 
@@ -19,7 +19,7 @@ type ProductPriceParams struct {
 	Amount                  int64                        	`json:"amount"`          // The price amount in cents.
 	Currency                string                       	`json:"currency"`         // The currency code (e.g., "dzd", "usd", "eur").
 	ProductID               string                       	`json:"product_id"`      // The ID of the product to which the price applies.
-	Metadata                 map[string]any              	`json:"metadata,omitempty"`  // Additional information about the price.
+	Metadata                 Metadata                    	`json:"metadata,omitempty"`  // Additional information about the price.
 }
 
 
@@ -28,14 +28,14 @@ type CreateProductParams struct {
     Name                   string                        	`json:"name,omitempty"`     // The name of the product.
     Description            string                        	`json:"description,omitempty"`  // The description of the product.
     Images                 []string                      	`json:"images,omitempty"`    // The URLs of images of the product, up to 8.
-    Metadata               map[string]any                	`json:"metadata,omitempty"`  // A set of key-value pairs for additional information about the product.
+    Metadata               Metadata                      	`json:"metadata,omitempty"`  // A set of key-value pairs for additional information about the product.
 }
 
 
 
 //update PriceMetaData 
 type UpdatePriceMetaDataParams struct {
-    Metadata                 map[string]any              	`json:"metadata"`  // Additional information about the price.
+    Metadata                 Metadata                    	`json:"metadata"`  // Additional information about the price.
 }
 
 
@@ -53,10 +53,10 @@ type CheckoutParams struct {
 	Description           	string          				`json:"description,omitempty"`         // Optional. Description of the checkout.
 	Locale                	string          				`json:"locale,omitempty"`              // Optional. Checkout page language (e.g., "en", "fr", "ar").
 	ShippingAddress       	string          				`json:"shipping_address,omitempty"`    // Optional. Customer's shipping address.
-	CollectShippingAddress 	bool           					`json:"collect_shipping_address,omitempty"` // Optional. Whether to collect the shipping address from the customer.
+	CollectShippingAddress 	Bool           					`json:"collect_shipping_address,omitempty"` // Optional. Whether to collect the shipping address from the customer.
 	PercentageDiscount    	int             				`json:"percentage_discount,omitempty"` // Optional. Percentage discount, prohibited if amount discount is provided.
 	AmountDiscount        	int             				`json:"amount_discount,omitempty"`     // Optional. Amount discount in cents, prohibited if percentage discount is provided.
-	Metadata              	map[string]any  				`json:"metadata,omitempty"`            // Optional. Additional key-value pairs for extra checkout information.
+	Metadata              	Metadata        				`json:"metadata,omitempty"`            // Optional. Additional key-value pairs for extra checkout information.
 }
 
 
@@ -68,8 +68,8 @@ type CreatePaymentLinkParams struct {
 	AfterCompletionMessage string            				`json:"after_completion_message"`     // A message displayed after order completion.
 	Locale                 string            				`json:"locale"`                       // The locale (e.g., "en", "fr").
 	PassFeesToCustomer     bool              				`json:"pass_fees_to_customer"`        // Indicates if fees are passed to the customer.
-	CollectShippingAddress int32              				`json:"collect_shipping_address"`     // Indicates whether to collect a shipping address.
-	Metadata               map[string]any    				`json:"metadata"`                     // Additional metadata for the order.
+	CollectShippingAddress Bool               				`json:"collect_shipping_address"`     // Indicates whether to collect a shipping address.
+	Metadata               Metadata          				`json:"metadata"`                     // Additional metadata for the order.
 }
 
 ////////////////////////////////////////////////////////////////////
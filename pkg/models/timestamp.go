@@ -0,0 +1,63 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+//******************************************************************//
+//========================== TIMESTAMP =============================//
+/////////////////////////////////////////////////////////////////////
+
+// Timestamp is a time sent by the API as Unix seconds (e.g. created_at), in UTC.
+// It decodes from a number, a string holding a number or an RFC 3339 time, and null. It encodes
+// back to Unix seconds, and to null when it is zero (decoded from null or missing).
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp returns the timestamp of t, truncated to the second like the API times.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{t.Truncate(time.Second).UTC()}
+}
+
+// UnixTimestamp returns the timestamp of the given Unix seconds.
+func UnixTimestamp(sec int64) Timestamp {
+	return Timestamp{time.Unix(sec, 0).UTC()}
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+
+	text := string(data)
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		if unquoted == "" {
+			*t = Timestamp{}
+			return nil
+		}
+		if parsed, err := time.Parse(time.RFC3339, unquoted); err == nil {
+			*t = NewTimestamp(parsed)
+			return nil
+		}
+		text = unquoted
+	}
+
+	sec, err := strconv.ParseInt(text, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp %s", data)
+	}
+	*t = UnixTimestamp(sec)
+	return nil
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.FormatInt(t.Unix(), 10)), nil
+}
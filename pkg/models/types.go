@@ -31,9 +31,9 @@ type ProductPrice struct {
 	Amount                  int64                        `json:"amount"`          // The price amount in cents.
 	Currency                string                       `json:"currency"`         // The currency code (e.g., "dzd", "usd", "eur").
 	ProductID               string                       `json:"product_id"`      // The ID of the product to which the price applies.
-	Metadata                map[string]any               `json:"metadata,omitempty"`         // Additional information about the price.
-	CreatedAt               int64                        `json:"created_at"`       // The timestamp of when the price was created.
-	UpdatedAt               int64                        `json:"updated_at"`       // The timestamp of when the price was updated.
+	Metadata                Metadata                     `json:"metadata,omitempty"`         // Additional information about the price.
+	CreatedAt               Timestamp                    `json:"created_at"`       // The timestamp of when the price was created.
+	UpdatedAt               Timestamp                    `json:"updated_at"`       // The timestamp of when the price was updated.
 }
 
 
@@ -55,9 +55,9 @@ type CheckoutItems struct {
 	Amount     				int64             		      `json:"amount"`                // The amount of the checkout item in cents.
 	Quantity   				int64             		      `json:"quantity"`              // The quantity of the checkout item.
 	Currency   				string            		      `json:"currency"`              // The currency of the checkout item (e.g., "dzd").
-	Metadata   				map[string]any    		      `json:"metadata,omitempty"`    // Optional metadata associated with the item.
-	CreatedAt  				int64             		      `json:"created_at"`            // The timestamp when the item was created.
-	UpdatedAt  				int64             		      `json:"updated_at"`            // The timestamp when the item was last updated.
+	Metadata   				Metadata          		      `json:"metadata,omitempty"`    // Optional metadata associated with the item.
+	CreatedAt  				Timestamp         		      `json:"created_at"`            // The timestamp when the item was created.
+	UpdatedAt  				Timestamp         		      `json:"updated_at"`            // The timestamp when the item was last updated.
 	ProductID  				string            		      `json:"product_id"`            // The unique identifier of the associated product.
 }
 
@@ -65,7 +65,7 @@ type CheckoutItems struct {
 type PItems struct {
 	Price              		string 						   `json:"price"`                		// The price of the item as a string.
 	Quantity           		int    						   `json:"quantity"`             		// The quantity of the item.
-	AdjustableQuantity 		bool   						   `json:"adjustable_quantity"`  		// Indicates if the quantity is adjustable by the customer.
+	AdjustableQuantity 		Bool   						   `json:"adjustable_quantity"`  		// Indicates if the quantity is adjustable by the customer.
 }
 
 
@@ -74,11 +74,11 @@ type PItemsData struct {
 	Entity            	string      						  `json:"entity"`              // The entity type (e.g., "price").
 	Amount            	int         						  `json:"amount"`              // The amount for the item.
 	Quantity          	int         						  `json:"quantity"`            // The quantity of the item.
-	AdjustableQuantity	int       						  	  `json:"adjustable_quantity"` // Indicates whether the quantity is adjustable (converted from 0 or 1 to bool).
+	AdjustableQuantity	Bool       						  	  `json:"adjustable_quantity"` // Indicates whether the quantity is adjustable (sent as 0 or 1).
 	Currency          	string      						  `json:"currency"`            // The currency code (e.g., "dzd").
-	Metadata          	interface{} 						  `json:"metadata"`            // Metadata associated with the item.
-	CreatedAt         	int64       						  `json:"created_at"`          // Timestamp when the item was created.
-	UpdatedAt         	int64       						  `json:"updated_at"`          // Timestamp when the item was last updated.
+	Metadata          	Metadata    						  `json:"metadata"`            // Metadata associated with the item.
+	CreatedAt         	Timestamp   						  `json:"created_at"`          // Timestamp when the item was created.
+	UpdatedAt         	Timestamp   						  `json:"updated_at"`          // Timestamp when the item was last updated.
 	ProductID         	string      						  `json:"product_id"`          // The associated product ID.
 }
 
@@ -101,9 +101,9 @@ type Customer struct {
     Email                   string                       `json:"email"`            // The email address of the customer.
     Phone                   string                       `json:"phone"`            // The phone number of the customer.
     Address                 *Address                     `json:"address"`          // The address of the customer.
-    Metadata                map[string]any               `json:"metadata"`         // Additional info about the customer.
-    UpdatedAt               int64                        `json:"updated_at"`       // The timestamp of when the customer was updates
-    CreatedAt               int64                        `json:"created_at"`       // The timestamp of when the customer was created.
+    Metadata                Metadata                     `json:"metadata"`         // Additional info about the customer.
+    UpdatedAt               Timestamp                    `json:"updated_at"`       // The timestamp of when the customer was updates
+    CreatedAt               Timestamp                    `json:"created_at"`       // The timestamp of when the customer was created.
 }
 
 
@@ -115,9 +115,9 @@ type Product struct {
     Name                    string                       `json:"name"`             // The name of the product.
     Description             string                       `json:"description"`      // The description of the product.
     Images                  []string                     `json:"images"`          // The URLs of images of the product, up to 8.
-    Metadata                map[string]any               `json:"metadata"`         // A set of key-value pairs for additional information about the product.
-    CreatedAt               int64                        `json:"created_at"`       // The timestamp of when the product was created.
-    UpdatedAt               int64                        `json:"updated_at"`       // The timestamp of when the product was updated.
+    Metadata                Metadata                     `json:"metadata"`         // A set of key-value pairs for additional information about the product.
+    CreatedAt               Timestamp                    `json:"created_at"`       // The timestamp of when the product was created.
+    UpdatedAt               Timestamp                    `json:"updated_at"`       // The timestamp of when the product was updated.
 }
 
 
@@ -136,7 +136,7 @@ type Checkout struct {
 	Status                  string            			  `json:"status"`                      // The status of the checkout (e.g., "pending", "succeeded", "failed").
 	Locale                  string            			  `json:"locale"`                      // The locale (e.g., "en", "fr", "es").
 	Description             *string           			  `json:"description"`                 // A description of the checkout. This can be null.
-	Metadata                *map[string]any   			  `json:"metadata"`                    // Additional information about the checkout. This can be null.
+	Metadata                Metadata          			  `json:"metadata"`                    // Additional information about the checkout, nil if empty.
 	SuccessURL              string            			  `json:"success_url"`                 // The URL to redirect to after a successful checkout.
 	FailureURL              string            			  `json:"failure_url"`                 // The URL to redirect to after a failed checkout.
 	WebhookEndpoint         *string           			  `json:"webhook_endpoint"`            // The URL to send a webhook to after the checkout. This can be null.
@@ -144,10 +144,10 @@ type Checkout struct {
 	InvoiceID               *string           			  `json:"invoice_id"`                  // The ID of the invoice associated with the checkout. This can be null.
 	CustomerID              string            			  `json:"customer_id"`                 // The ID of the customer associated with the checkout.
 	PaymentLinkID           *string           			  `json:"payment_link_id"`             // The ID of the payment link associated with the checkout. This can be null.
-	CreatedAt               int64             			  `json:"created_at"`                  // The timestamp of when the checkout was created.
-	UpdatedAt               int64             			  `json:"updated_at"`                  // The timestamp of when the checkout was updated.
+	CreatedAt               Timestamp         			  `json:"created_at"`                  // The timestamp of when the checkout was created.
+	UpdatedAt               Timestamp         			  `json:"updated_at"`                  // The timestamp of when the checkout was updated.
 	ShippingAddress         *string           			  `json:"shipping_address"`            // The shipping address to be associated with the checkout. This can be null.
-	CollectShippingAddress  int32             			  `json:"collect_shipping_address"`    // Indicates whether the shipping address should be collected.
+	CollectShippingAddress  Bool              			  `json:"collect_shipping_address"`    // Indicates whether the shipping address should be collected.
 	Discount               	Discount		  			  `json:"discount"` // The discount applied to the checkout.
 	AmountWithoutDiscount   int64   		  			  `json:"amount_without_discount"`  // The amount without any discount.
 	CheckoutURL             string  		  			  `json:"checkout_url"`             // The URL to access the checkout page.
@@ -160,14 +160,14 @@ type PaymentLink struct {
 	Entity                 string  						  `json:"entity"`                      // The entity type (e.g., "payment_link").
 	Livemode               bool    						  `json:"livemode"`                    // Indicates whether the mode is live.
 	Name                   string  						  `json:"name"`                        // The name or description of the payment link.
-	Active                 int     						  `json:"active"`                      // Indicates if the payment link is active (1) or inactive (0).
+	Active                 Bool    						  `json:"active"`                      // Indicates if the payment link is active (sent as 1) or inactive (0).
 	AfterCompletionMessage string  						  `json:"after_completion_message"`    // A message displayed to the user after payment is completed.
 	Locale                 string  						  `json:"locale"`                      // The locale (e.g., "ar", "en").
 	PassFeesToCustomer      bool   						   `json:"pass_fees_to_customer"`      // Indicates whether the fees are passed to the customer.
-	Metadata                map[string]any  						   `json:"metadata"`                   // Additional metadata associated with the payment link.
-	CreatedAt              int64   						  `json:"created_at"`                  // The timestamp when the payment link was created.
-	UpdatedAt              int64   						  `json:"updated_at"`                  // The timestamp when the payment link was last updated.
-	CollectShippingAddress int32    						  `json:"collect_shipping_address"`   // Indicates whether the shipping address should be collected (0 or 1).
+	Metadata                Metadata        						   `json:"metadata"`                   // Additional metadata associated with the payment link.
+	CreatedAt              Timestamp					  `json:"created_at"`                  // The timestamp when the payment link was created.
+	UpdatedAt              Timestamp					  `json:"updated_at"`                  // The timestamp when the payment link was last updated.
+	CollectShippingAddress Bool     						  `json:"collect_shipping_address"`   // Indicates whether the shipping address should be collected (0 or 1).
 	URL                    string  						  `json:"url"`                         // The URL to access the payment link.
 }
 
@@ -179,8 +179,8 @@ type WebhookEvent struct {
 	LiveMode  bool         `json:"livemode,string"`
 	Type      string       `json:"type"`
 	Data      eventData    `json:"data"`
-	CreatedAt int64        `json:"created_at"`
-	UpdatedAt int64        `json:"updated_at"`
+	CreatedAt Timestamp    `json:"created_at"`
+	UpdatedAt Timestamp    `json:"updated_at"`
 }
 
 // Nested checkout data structure
@@ -191,10 +191,10 @@ type eventData struct {
 	Amount                   int     `json:"amount"`
 	Locale                   string  `json:"locale"`
 	Status                   string  `json:"status"`
-	Metadata                 *string `json:"metadata"` // nullable field
-	CreatedAt                int64   `json:"created_at"`
+	Metadata                 Metadata `json:"metadata"` // nullable field, may be sent as a JSON string
+	CreatedAt                Timestamp   `json:"created_at"`
 	InvoiceID                *string `json:"invoice_id"` // nullable field
-	UpdatedAt                int64   `json:"updated_at"`
+	UpdatedAt                Timestamp   `json:"updated_at"`
 	CustomerID               string  `json:"customer_id"`
 	Description              *string `json:"description"` // nullable field
 	FailureURL               *string `json:"failure_url"` // nullable field
@@ -204,7 +204,7 @@ type eventData struct {
 	PassFeesToCustomer       *bool   `json:"pass_fees_to_customer"` // nullable field
 	ChargilyPayFeesAllocation string  `json:"chargily_pay_fees_allocation"`
 	ShippingAddress          *string `json:"shipping_address"` // nullable field
-	CollectShippingAddress   int     `json:"collect_shipping_address"`
+	CollectShippingAddress   Bool    `json:"collect_shipping_address"`
 	Discount                 *int    `json:"discount"`               // nullable field
 	AmountWithoutDiscount    *int    `json:"amount_without_discount"` // nullable field
 	URL                      string  `json:"url"`
@@ -0,0 +1,37 @@
+package otel
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// maxPeekSize is the largest response body read to find the ID of the returned object
+const maxPeekSize = 1 << 20
+
+// peekObjectID returns the "id" field of a JSON response body, the body is left readable for the client
+func peekObjectID(res *http.Response) string {
+	if res == nil || res.Body == nil || res.ContentLength > maxPeekSize {
+		return ""
+	}
+
+	body := res.Body
+	raw, err := io.ReadAll(io.LimitReader(body, maxPeekSize+1))
+	// the client reads the peeked bytes first, then the rest of the body if any
+	res.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(raw), body), body}
+	if err != nil || len(raw) > maxPeekSize {
+		return ""
+	}
+
+	var object struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(raw, &object) != nil {
+		return ""
+	}
+	return object.ID
+}
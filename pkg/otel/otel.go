@@ -0,0 +1,203 @@
+// Package otel instruments the Chargily client with OpenTelemetry traces and metrics.
+//
+// Nothing is recorded unless the client is created with the Instrument option, and the global
+// OpenTelemetry providers (no-op until configured) are used unless other providers are given.
+package otel
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// ScopeName is the instrumentation scope of the tracer and the meter.
+const ScopeName = "github.com/Chargily/chargily-pay-go/pkg/otel"
+
+// Attribute keys set on the spans and the metrics
+const (
+	ResourceKey   = attribute.Key("chargily.resource")
+	OperationKey  = attribute.Key("chargily.operation")
+	ObjectIDKey   = attribute.Key("chargily.object_id")
+	RetryCountKey = attribute.Key("chargily.retry_count")
+	EventIDKey    = attribute.Key("chargily.event.id")
+	EventTypeKey  = attribute.Key("chargily.event.type")
+	LivemodeKey   = attribute.Key("chargily.livemode")
+)
+
+// Option customizes the instrumentation.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider records the spans with the given provider instead of the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) { c.tracerProvider = tp }
+}
+
+// WithMeterProvider records the metrics with the given provider instead of the global one.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) { c.meterProvider = mp }
+}
+
+// Instrument returns the client option recording a span and metrics for every API request,
+// and spans around the webhook signature verification and handler execution.
+func Instrument(opts ...Option) chargily.ClientOption {
+	c := config{
+		tracerProvider: global.GetTracerProvider(),
+		meterProvider:  global.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	tracer := c.tracerProvider.Tracer(ScopeName)
+	m := newMetrics(c.meterProvider.Meter(ScopeName))
+
+	middleware := chargily.WithMiddleware(newMiddleware(tracer, m))
+	webhook := chargily.WithWebhookTracer(&webhookTracer{tracer: tracer})
+	return func(client *chargily.Client) {
+		middleware(client)
+		webhook(client)
+	}
+}
+
+// metrics holds the instruments recording the API requests
+type metrics struct {
+	duration metric.Float64Histogram
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	retries  metric.Int64Counter
+}
+
+func newMetrics(meter metric.Meter) *metrics {
+	var m metrics
+	var err error
+
+	m.duration, err = meter.Float64Histogram("chargily.client.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of the requests sent to the Chargily API."))
+	handle(err)
+	m.requests, err = meter.Int64Counter("chargily.client.requests",
+		metric.WithDescription("Number of requests sent to the Chargily API."))
+	handle(err)
+	m.errors, err = meter.Int64Counter("chargily.client.errors",
+		metric.WithDescription("Number of requests that failed or got a non 2xx response."))
+	handle(err)
+	m.retries, err = meter.Int64Counter("chargily.client.retries",
+		metric.WithDescription("Number of requests sent again after a throttled attempt."))
+	handle(err)
+	return &m
+}
+
+// handle reports the errors of the instruments creation to the global error handler
+func handle(err error) {
+	if err != nil {
+		global.Handle(err)
+	}
+}
+
+// newMiddleware records a client span and the metrics of every request
+func newMiddleware(tracer trace.Tracer, m *metrics) chargily.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return chargily.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			info, _ := utils.RequestInfoFromContext(req.Context())
+
+			attrs := []attribute.KeyValue{
+				ResourceKey.String(info.Resource),
+				OperationKey.String(info.Operation),
+			}
+			ctx, span := tracer.Start(req.Context(), "chargily "+info.Resource+"."+info.Operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(
+					RetryCountKey.Int(info.Attempt),
+					attribute.String("http.request.method", req.Method),
+					attribute.String("server.address", req.URL.Host),
+				),
+			)
+			defer span.End()
+
+			start := time.Now()
+			res, err := next.RoundTrip(req.WithContext(ctx))
+			elapsed := time.Since(start).Seconds()
+
+			failed := err != nil || res.StatusCode < 200 || res.StatusCode >= 300
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			} else {
+				attrs = append(attrs, attribute.Int("http.response.status_code", res.StatusCode))
+				span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+				if failed {
+					span.SetStatus(codes.Error, res.Status)
+				}
+			}
+
+			objectID := info.ObjectID
+			if objectID == "" && !failed {
+				objectID = peekObjectID(res)
+			}
+			if objectID != "" {
+				span.SetAttributes(ObjectIDKey.String(objectID))
+			}
+
+			measured := metric.WithAttributes(attrs...)
+			m.duration.Record(ctx, elapsed, measured)
+			m.requests.Add(ctx, 1, measured)
+			if failed {
+				m.errors.Add(ctx, 1, measured)
+			}
+			if info.Attempt > 0 {
+				m.retries.Add(ctx, 1, measured)
+			}
+			return res, err
+		})
+	}
+}
+
+// webhookTracer records the spans of the webhook handler
+type webhookTracer struct {
+	tracer trace.Tracer
+}
+
+func (t *webhookTracer) StartVerify(ctx context.Context) func(error) {
+	_, span := t.tracer.Start(ctx, "chargily webhook.verify", trace.WithSpanKind(trace.SpanKindInternal))
+	return endSpan(span)
+}
+
+// StartHandle starts the span of the handling, the handler gets its context to create child spans
+func (t *webhookTracer) StartHandle(ctx context.Context, event *models.WebhookEvent) (context.Context, func(error)) {
+	ctx, span := t.tracer.Start(ctx, "chargily webhook.handle",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			EventIDKey.String(event.ID),
+			EventTypeKey.String(event.Type),
+			LivemodeKey.Bool(event.LiveMode),
+			ObjectIDKey.String(event.Data.ID),
+		),
+	)
+	return ctx, endSpan(span)
+}
+
+// endSpan returns the function ending the span with the outcome of its step
+func endSpan(span trace.Span) func(error) {
+	return func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,164 @@
+package otel_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	chargilyotel "github.com/Chargily/chargily-pay-go/pkg/otel"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// redirectTo sends every request of the client to the test server
+func redirectTo(server *httptest.Server) chargily.Middleware {
+	target, _ := url.Parse(server.URL)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return chargily.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func TestOtelInstrumentClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.Customer{ID: "cus_123", Name: "Alice"})
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	client, err := chargily.NewClient("test-api-key", "test",
+		chargilyotel.Instrument(
+			chargilyotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+			chargilyotel.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		),
+		chargily.WithMiddleware(redirectTo(server)),
+	)
+	assert.NoError(t, err)
+
+	customer, err := client.Customers.Create(&models.CreateCustomerParams{Name: "Alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, "cus_123", customer.ID)
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 1)
+	assert.Equal(t, "chargily customers.create", ended[0].Name())
+	assert.Contains(t, ended[0].Attributes(), chargilyotel.ObjectIDKey.String("cus_123"))
+	assert.Contains(t, ended[0].Attributes(), chargilyotel.RetryCountKey.Int(0))
+	assert.Contains(t, ended[0].Attributes(), attribute.Int("http.response.status_code", 200))
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	counts := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range sum.DataPoints {
+					counts[m.Name] += dp.Value
+				}
+			}
+		}
+	}
+	assert.Equal(t, int64(1), counts["chargily.client.requests"])
+	assert.Equal(t, int64(0), counts["chargily.client.errors"])
+}
+
+func TestOtelInstrumentWebhook(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	client, err := chargily.NewClient("test-api-key", "test",
+		chargilyotel.Instrument(chargilyotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))),
+	)
+	assert.NoError(t, err)
+
+	var handled bool
+	client.Webhook.SetupHandler("/otel-webhook", func(eventType string, event models.WebhookEvent) {
+		handled = true
+	})
+	server := httptest.NewServer(http.DefaultServeMux)
+	defer server.Close()
+
+	payload := []byte(`{"id":"evt_1","type":"checkout.paid","livemode":"false","data":{"id":"chk_1"}}`)
+	mac := hmac.New(sha256.New, []byte("test-api-key"))
+	mac.Write(payload)
+
+	req, _ := http.NewRequest("POST", server.URL+"/otel-webhook", bytes.NewReader(payload))
+	req.Header.Set("signature", hex.EncodeToString(mac.Sum(nil)))
+	res, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.True(t, handled)
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 2)
+	assert.Equal(t, "chargily webhook.verify", ended[0].Name())
+	assert.Equal(t, "chargily webhook.handle", ended[1].Name())
+	assert.Contains(t, ended[1].Attributes(), chargilyotel.EventTypeKey.String("checkout.paid"))
+	assert.Contains(t, ended[1].Attributes(), chargilyotel.ObjectIDKey.String("chk_1"))
+}
+
+// signedRequest returns a webhook request of the event signed with the API key
+func signedRequest(payload []byte) *http.Request {
+	mac := hmac.New(sha256.New, []byte("test-api-key"))
+	mac.Write(payload)
+
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(payload))
+	req.Header.Set("signature", hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestOtelWebhookHandlerSpans(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))
+	client, err := chargily.NewClient("test-api-key", "test", chargilyotel.Instrument(chargilyotel.WithTracerProvider(provider)))
+	assert.NoError(t, err)
+
+	payload := []byte(`{"id":"evt_1","type":"checkout.paid","livemode":"false","data":{"id":"chk_1"}}`)
+
+	// the handler creates its spans under the span of the handling
+	handler := client.Webhook.ContextHandler(func(ctx context.Context, event models.WebhookEvent) error {
+		_, span := provider.Tracer("app").Start(ctx, "fulfill order")
+		span.End()
+		return nil
+	})
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, signedRequest(payload))
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 3)
+	assert.Equal(t, "fulfill order", ended[1].Name())
+	assert.Equal(t, "chargily webhook.handle", ended[2].Name())
+	assert.Equal(t, ended[2].SpanContext().SpanID(), ended[1].Parent().SpanID())
+	assert.Equal(t, trace.SpanKindInternal, ended[2].SpanKind())
+
+	// the span of a panicking handler is ended with the panic
+	handler = client.Webhook.Handler(func(eventType string, event models.WebhookEvent) {
+		panic("boom")
+	})
+	assert.Panics(t, func() { handler.ServeHTTP(httptest.NewRecorder(), signedRequest(payload)) })
+
+	ended = spans.Ended()
+	assert.Len(t, ended, 5)
+	assert.Equal(t, "chargily webhook.handle", ended[4].Name())
+	assert.Equal(t, codes.Error, ended[4].Status().Code)
+	assert.Equal(t, "panic: boom", ended[4].Status().Description)
+}
@@ -0,0 +1,104 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/analytics"
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyticsCompute(t *testing.T) {
+	day1 := models.NewTimestamp(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	day2 := models.NewTimestamp(time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC))
+	edahabia := "edahabia"
+	link := "link_1"
+
+	checkouts := []models.Checkout{
+		{Status: "paid", Currency: "dzd", Amount: 9000, AmountWithoutDiscount: 10000, Fees: 200, FeesOnMerchant: 150, FeesOnCustomer: 50, PaymentMethod: &edahabia, CreatedAt: day1},
+		{Status: "paid", Currency: "dzd", Amount: 5000, AmountWithoutDiscount: 5000, Fees: 100, FeesOnMerchant: 100, PaymentLinkID: &link, CreatedAt: day2},
+		{Status: "failed", Currency: "dzd", Amount: 3000, CreatedAt: day2},
+		{Status: "pending", Currency: "dzd", Amount: 1000, CreatedAt: day2},
+	}
+
+	report := analytics.Compute(checkouts)
+
+	assert.Equal(t, 4, report.Checkouts)
+	assert.Equal(t, 2, report.Paid)
+	assert.Equal(t, int64(14000), report.GrossVolume)
+	assert.Equal(t, int64(300), report.Fees)
+	assert.Equal(t, int64(250), report.FeesOnMerchant)
+	assert.Equal(t, int64(50), report.FeesOnCustomer)
+	assert.Equal(t, int64(13750), report.NetRevenue)
+	assert.Equal(t, int64(1000), report.Discounts)
+	assert.Equal(t, 0.5, report.ConversionRate)
+
+	assert.Len(t, report.ByDay, 2)
+	assert.Equal(t, "2024-05-01", report.ByDay[0].Key)
+	assert.Equal(t, 1.0, report.ByDay[0].ConversionRate)
+	assert.Equal(t, 3, report.ByDay[1].Checkouts)
+
+	assert.Equal(t, []string{"edahabia", "unknown"}, keys(report.ByPaymentMethod))
+	assert.Equal(t, []string{"link_1", "none"}, keys(report.ByPaymentLink))
+	assert.Equal(t, []string{"dzd"}, keys(report.ByCurrency))
+}
+
+func TestAnalyticsWriteCSV(t *testing.T) {
+	report := analytics.Compute([]models.Checkout{
+		{Status: "paid", Currency: "dzd", Amount: 1000, Fees: 10, FeesOnMerchant: 10, CreatedAt: models.UnixTimestamp(0)},
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&buf))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, "group,key,checkouts,paid,gross_volume,fees,fees_on_merchant,fees_on_customer,net_revenue,discounts,conversion_rate", lines[0])
+	assert.Equal(t, "total,,1,1,1000,10,10,0,990,0,1.0000", lines[1])
+	assert.Equal(t, "day,1970-01-01,1,1,1000,10,10,0,990,0,1.0000", lines[2])
+	assert.Len(t, lines, 6)
+}
+
+// keys returns the keys of the breakdown entries
+func keys(entries []analytics.Breakdown) []string {
+	result := make([]string, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.Key)
+	}
+	return result
+}
+
+func TestAnalyticsGenerateCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			next := chargily.TestAPIBaseUrl + "checkouts?page=2"
+			json.NewEncoder(w).Encode(map[string]any{
+				"current_page": 1, "next_page_url": next,
+				"data": []map[string]any{{"id": "chk_1", "status": "paid", "amount": 1000, "currency": "dzd"}},
+			})
+			return
+		}
+		// a slow page, until the request is canceled
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client, _ := chargily.NewClient("test_api_key", "test", chargily.WithMiddleware(redirectTo(server)))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := analytics.Generate(ctx, client)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
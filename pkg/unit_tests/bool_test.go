@@ -0,0 +1,41 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoolUnmarshal(t *testing.T) {
+	for raw, expected := range map[string]bool{
+		`true`: true, `false`: false, `1`: true, `0`: false, `null`: false,
+		`"1"`: true, `"0"`: false, `"true"`: true, `"FALSE"`: false, `""`: false,
+	} {
+		b := models.Bool(!expected)
+		assert.NoError(t, json.Unmarshal([]byte(raw), &b), raw)
+		assert.Equal(t, expected, bool(b), raw)
+	}
+
+	var b models.Bool
+	assert.Error(t, json.Unmarshal([]byte(`2`), &b))
+	assert.Error(t, json.Unmarshal([]byte(`"yes"`), &b))
+}
+
+func TestBoolSymmetricModels(t *testing.T) {
+	var link models.PaymentLink
+	raw := `{"active": 1, "collect_shipping_address": "0"}`
+	assert.NoError(t, json.Unmarshal([]byte(raw), &link))
+	assert.True(t, bool(link.Active))
+	assert.False(t, bool(link.CollectShippingAddress))
+
+	// the params are sent with JSON booleans
+	encoded, err := json.Marshal(models.CreatePaymentLinkParams{CollectShippingAddress: link.Active})
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"collect_shipping_address":true`)
+
+	var items models.RetrieveAll[models.PItemsData]
+	assert.NoError(t, json.Unmarshal([]byte(`{"data": [{"adjustable_quantity": 1}]}`), &items))
+	assert.True(t, bool(items.Data[0].AdjustableQuantity))
+}
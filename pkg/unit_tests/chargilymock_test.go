@@ -0,0 +1,58 @@
+package unit_tests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargilymock"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// payOrder is an example of downstream code depending on the client interface
+func payOrder(api chargily.ClientAPI, customerEmail string) (string, error) {
+	customer, err := api.CustomersService().Create(&models.CreateCustomerParams{Email: customerEmail}, chargily.WithNewIdempotencyKey())
+	if err != nil {
+		return "", err
+	}
+
+	checkout, err := api.CheckoutsService().Create(&models.CheckoutParams{
+		Amount:     1000,
+		Currency:   "dzd",
+		CustomerID: customer.ID,
+		SuccessURL: "https://example.com/success",
+	})
+	if err != nil {
+		return "", err
+	}
+	return checkout.CheckoutURL, nil
+}
+
+func TestChargilyMockClient(t *testing.T) {
+	client := chargilymock.NewClient()
+	// the customer is created with an idempotency key
+	client.Customers.On("Create", &models.CreateCustomerParams{Email: "alice@example.com"}, mock.MatchedBy(func(opt chargily.RequestOption) bool {
+		return chargily.IdempotencyKeyOf(opt) != ""
+	})).Return(&models.Customer{ID: "cus_1"}, nil)
+	client.Checkouts.On("Create", mock.MatchedBy(func(params *models.CheckoutParams) bool {
+		return params.CustomerID == "cus_1"
+	})).Return(&models.Checkout{CheckoutURL: "https://pay.chargily.net/checkout/1"}, nil)
+
+	url, err := payOrder(client, "alice@example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://pay.chargily.net/checkout/1", url)
+	client.AssertExpectations(t)
+}
+
+func TestChargilyMockError(t *testing.T) {
+	client := chargilymock.NewClient()
+	client.Customers.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, errors.New("failed with status: 422"))
+
+	_, err := payOrder(client, "not-an-email")
+
+	assert.EqualError(t, err, "failed with status: 422")
+	client.Checkouts.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -0,0 +1,135 @@
+package unit_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeConfig writes a configuration file in a temporary directory
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfigFormats(t *testing.T) {
+	retries := 5
+	expected := &chargily.Config{
+		APIKey:        "config_key",
+		Mode:          chargily.Prod,
+		BaseURL:       "https://proxy.example.com/api/v2/",
+		Timeout:       30 * time.Second,
+		MaxRetries:    &retries,
+		RateLimit:     2.5,
+		RateBurst:     4,
+		WebhookSecret: "whsec",
+	}
+
+	files := map[string]string{
+		"config.json": `{"api_key": "config_key", "mode": "prod", "base_url": "https://proxy.example.com/api/v2/",
+			"timeout": 30, "max_retries": 5, "rate_limit": 2.5, "rate_burst": 4, "webhook_secret": "whsec"}`,
+		"config.yaml": `
+api_key: config_key
+mode: prod
+base_url: https://proxy.example.com/api/v2/
+timeout: 30s
+max_retries: 5
+rate_limit: 2.5
+rate_burst: 4
+webhook_secret: whsec
+`,
+		".env": `
+# chargily
+export CHARGILY_API_KEY="config_key"
+CHARGILY_MODE=prod
+CHARGILY_BASE_URL=https://proxy.example.com/api/v2/
+CHARGILY_TIMEOUT=30s
+CHARGILY_MAX_RETRIES=5
+CHARGILY_RATE_LIMIT=2.5
+CHARGILY_RATE_BURST=4
+webhook_secret='whsec'
+`,
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			config, err := chargily.LoadConfig(writeConfig(t, name, content))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, config)
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     error
+		message string
+	}{
+		{"missing key", `mode=test`, chargily.ErrMissingAPIKey, "api_key is not set"},
+		{"invalid mode", "api_key=key\nmode=live", utils.ErrInvalidMode, `mode must be "test" or "prod", got "live"`},
+		{"invalid timeout", "api_key=key\ntimeout=soon", chargily.ErrInvalidConfig, `timeout must be a positive duration`},
+		{"infinite timeout", "api_key=key\ntimeout=Inf", chargily.ErrInvalidConfig, `timeout must be a positive duration`},
+		{"NaN timeout", "api_key=key\ntimeout=NaN", chargily.ErrInvalidConfig, `timeout must be a positive duration`},
+		{"overflowing timeout", "api_key=key\ntimeout=1e300", chargily.ErrInvalidConfig, `timeout must be a positive duration`},
+		{"infinite rate", "api_key=key\nrate_limit=Inf", chargily.ErrInvalidConfig, `rate_limit must be a positive number`},
+		{"NaN rate", "api_key=key\nrate_limit=NaN", chargily.ErrInvalidConfig, `rate_limit must be a positive number`},
+		{"invalid base url", "api_key=key\nbase_url=proxy:8080", chargily.ErrInvalidConfig, `base_url must be an http or https URL`},
+		{"burst without rate", "api_key=key\nrate_burst=2", chargily.ErrInvalidConfig, `rate_burst needs`},
+		{"unknown key", "api_key=key\napi_secret=x", chargily.ErrInvalidConfig, `line 2: unknown key "api_secret"`},
+		{"two keys", "api_key=key\napi_key_file=/run/key", chargily.ErrInvalidConfig, `api_key_file can't be set`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := chargily.LoadConfig(writeConfig(t, "chargily.env", tt.content))
+			assert.ErrorIs(t, err, tt.err)
+			assert.ErrorContains(t, err, tt.message)
+		})
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"entity":"balance"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("CHARGILY_API_KEY", "env_key")
+	t.Setenv("CHARGILY_MODE", "")
+	t.Setenv("CHARGILY_BASE_URL", server.URL+"/v2")
+	t.Setenv("CHARGILY_WEBHOOK_SECRET", "whsec")
+
+	client, err := chargily.NewClientFromEnv()
+	assert.NoError(t, err)
+	_, err = client.Balance.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/v2/balance"}, paths)
+
+	// the webhooks are signed with the secret rather than the API key
+	payload := []byte(`{"id":"evt_1"}`)
+	assert.NoError(t, client.Webhook.VerifySignature(payload, signPayload(payload, "whsec")))
+	assert.ErrorIs(t, client.Webhook.VerifySignature(payload, signPayload(payload, "env_key")), chargily.ErrInvalidSignature)
+
+	t.Setenv("CHARGILY_TIMEOUT", "-1s")
+	_, err = chargily.NewClientFromEnv()
+	assert.ErrorIs(t, err, chargily.ErrInvalidConfig)
+	assert.ErrorContains(t, err, "CHARGILY_TIMEOUT must be a positive duration")
+
+	// an infinite rate is refused rather than failing the rate limiter
+	t.Setenv("CHARGILY_TIMEOUT", "")
+	t.Setenv("CHARGILY_RATE_LIMIT", "Inf")
+	_, err = chargily.NewClientFromEnv()
+	assert.ErrorIs(t, err, chargily.ErrInvalidConfig)
+	assert.ErrorContains(t, err, "CHARGILY_RATE_LIMIT must be a positive number")
+}
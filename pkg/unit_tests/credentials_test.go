@@ -0,0 +1,68 @@
+package unit_tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCredentialsRotation(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		w.Write([]byte(`{"entity":"balance"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "api_key")
+	assert.NoError(t, os.WriteFile(path, []byte("old_key\n"), 0o600))
+
+	client, err := chargily.NewClient("", "test",
+		chargily.WithCredentials(chargily.NewFileCredentials(path)),
+		chargily.WithMiddleware(redirectTo(server)))
+	assert.NoError(t, err)
+
+	_, err = client.Balance.Get()
+	assert.NoError(t, err)
+
+	// the rotated key is used by the next request, and for the webhook signatures
+	assert.NoError(t, os.WriteFile(path, []byte("rotated_key\n"), 0o600))
+	later := time.Now().Add(time.Second)
+	assert.NoError(t, os.Chtimes(path, later, later))
+
+	_, err = client.Balance.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bearer old_key", "Bearer rotated_key"}, authorizations)
+
+	payload := []byte(`{"id":"evt_1"}`)
+	assert.NoError(t, client.Webhook.VerifySignature(payload, signPayload(payload, "rotated_key")))
+	assert.ErrorIs(t, client.Webhook.VerifySignature(payload, signPayload(payload, "old_key")), chargily.ErrInvalidSignature)
+
+	assert.Equal(t, "key: [REDACTED]", client.Redact("key: rotated_key"))
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv("CHARGILY_TEST_KEY", "")
+	credentials := chargily.EnvCredentials("CHARGILY_TEST_KEY")
+
+	_, err := credentials.APIKey(context.Background())
+	assert.ErrorIs(t, err, chargily.ErrMissingAPIKey)
+
+	client, _ := chargily.NewClient("", "test", chargily.WithCredentials(credentials))
+	payload := []byte(`{"id":"evt_1"}`)
+	err = client.Webhook.VerifySignature(payload, signPayload(payload, ""))
+	assert.ErrorIs(t, err, chargily.ErrMissingAPIKey)
+	assert.NotErrorIs(t, err, chargily.ErrInvalidSignature)
+
+	t.Setenv("CHARGILY_TEST_KEY", "env_key")
+	key, err := credentials.APIKey(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "env_key", key)
+}
@@ -0,0 +1,110 @@
+package unit_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/Chargily/chargily-pay-go/pkg/chargilytest"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixturesMatchModels(t *testing.T) {
+	chargilytest.CheckFixtureFile(t, "testdata/fixtures/checkout.json", models.Checkout{})
+}
+
+func TestCheckDrift(t *testing.T) {
+	raw := []byte(`{
+		"data": [{"id": "plink_1", "active": true, "name": 5, "new_field": {"a": 1}}],
+		"current_page": 1,
+		"next_page_url": null,
+		"total": "1"
+	}`)
+
+	drifts, err := utils.CheckDrift(raw, &models.RetrieveAll[models.PaymentLink]{})
+	assert.NoError(t, err)
+	assert.Len(t, drifts, 3)
+
+	assert.Equal(t, utils.TypeMismatch, drifts[0].Kind)
+	assert.Equal(t, "data[0].name", drifts[0].Path)
+	assert.Equal(t, "string", drifts[0].Expected)
+	assert.Equal(t, "number", drifts[0].Got)
+
+	assert.Equal(t, utils.UnknownField, drifts[1].Kind)
+	assert.Equal(t, "data[0].new_field", drifts[1].Path)
+	assert.Equal(t, "object", drifts[1].Got)
+
+	assert.Equal(t, "total", drifts[2].Path)
+	assert.Equal(t, "models.RetrieveAll[github.com/Chargily/chargily-pay-go/pkg/models.PaymentLink]", drifts[2].Model)
+
+	// a document matching its model has no drift
+	drifts, err = utils.CheckDrift([]byte(`{"id": "cus_1", "address": {"city": "Oran"}, "metadata": {"k": [1]}}`), models.Customer{})
+	assert.NoError(t, err)
+	assert.Empty(t, drifts)
+}
+
+func TestCheckDriftDecodingTypes(t *testing.T) {
+	// the lenient types accept the JSON types the API sends them as
+	drifts, err := utils.CheckDrift([]byte(`{
+		"id": "plink_1",
+		"active": "1",
+		"collect_shipping_address": false,
+		"metadata": "{\"order_id\": \"o-1\"}",
+		"created_at": 1714557600,
+		"updated_at": "2024-05-01T10:00:00Z"
+	}`), models.PaymentLink{})
+	assert.NoError(t, err)
+	assert.Empty(t, drifts)
+
+	// and not the others
+	drifts, err = utils.CheckDrift([]byte(`{
+		"id": "plink_1",
+		"active": "maybe",
+		"collect_shipping_address": [true],
+		"metadata": [1],
+		"created_at": {"seconds": 1714557600},
+		"updated_at": true
+	}`), models.PaymentLink{})
+	assert.NoError(t, err)
+
+	var paths, expected, got []string
+	for _, drift := range drifts {
+		assert.Equal(t, utils.TypeMismatch, drift.Kind)
+		paths = append(paths, drift.Path)
+		expected = append(expected, drift.Expected)
+		got = append(got, drift.Got)
+	}
+	assert.Equal(t, []string{"active", "collect_shipping_address", "created_at", "metadata", "updated_at"}, paths)
+	assert.Equal(t, []string{"models.Bool", "models.Bool", "models.Timestamp", "models.Metadata", "models.Timestamp"}, expected)
+	assert.Equal(t, []string{"string", "array", "object", "array", "bool"}, got)
+}
+
+func TestStrictDecodingReportsDrift(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "cus_1", "name": "Alice", "tier": "gold"}`))
+	}))
+	defer server.Close()
+
+	var drifts []chargily.Drift
+	client, _ := chargily.NewClient("test_api_key", "test",
+		chargily.WithMiddleware(redirectTo(server)),
+		chargily.WithStrictDecoding(func(drift chargily.Drift) { drifts = append(drifts, drift) }),
+	)
+
+	// the response is still decoded
+	customer, err := client.Customers.Get("cus_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", customer.Name)
+
+	assert.Equal(t, []chargily.Drift{{
+		Kind:      utils.UnknownField,
+		Path:      "tier",
+		Model:     "models.Customer",
+		Got:       "string",
+		Resource:  "customers",
+		Operation: "get",
+	}}, drifts)
+}
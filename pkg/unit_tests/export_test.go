@@ -0,0 +1,117 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/export"
+	"github.com/stretchr/testify/assert"
+)
+
+// customersServer serves two pages of customers, the API ignoring the created range of the query
+func customersServer(queries *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+
+		if r.URL.Query().Get("page") == "" {
+			next := chargily.TestAPIBaseUrl + "customers?page=2"
+			json.NewEncoder(w).Encode(map[string]any{
+				"current_page":  1,
+				"next_page_url": next,
+				"data": []map[string]any{
+					{
+						"id": "cus_1", "livemode": false, "name": "Alice", "email": "alice@example.com", "phone": "0550000000",
+						"address":    map[string]any{"city": "Algiers", "country": "DZ"},
+						"metadata":   map[string]any{"order_id": "o-1", "tier": 2},
+						"created_at": 1714557600, "updated_at": 1714557600,
+					},
+					{"id": "cus_old", "name": "Too old", "created_at": 1000, "updated_at": 1000},
+				},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"current_page": 2,
+			"data": []map[string]any{
+				{"id": "cus_2", "livemode": false, "name": "Bob, Jr.", "created_at": 1714644000, "updated_at": 1714644000},
+			},
+		})
+	}))
+}
+
+func TestExportCustomersCSV(t *testing.T) {
+	var queries []string
+	server := customersServer(&queries)
+	defer server.Close()
+
+	client, _ := chargily.NewClient("test_api_key", "test", chargily.WithMiddleware(redirectTo(server)))
+
+	var buf bytes.Buffer
+	err := export.Customers(context.Background(), client, &buf, export.CSV,
+		export.CreatedAfter(time.Unix(1700000000, 0)), export.MetadataKeys("order_id", "tier", "missing"))
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		"id,livemode,name,email,phone,address.address,address.city,address.state,address.zip_code,address.country,metadata,created_at,updated_at,metadata.order_id,metadata.tier,metadata.missing",
+		`cus_1,false,Alice,alice@example.com,0550000000,,Algiers,,,DZ,"{""order_id"":""o-1"",""tier"":2}",2024-05-01T10:00:00Z,2024-05-01T10:00:00Z,o-1,2,`,
+		`cus_2,false,"Bob, Jr.",,,,,,,,,2024-05-02T10:00:00Z,2024-05-02T10:00:00Z,,,`,
+	}, lines)
+
+	// the created range is sent with every page, and applied to the objects the API returns anyway
+	assert.Equal(t, []string{"created_after=1700000000", "created_after=1700000000&page=2"}, queries)
+}
+
+func TestExportCustomersJSONL(t *testing.T) {
+	var queries []string
+	server := customersServer(&queries)
+	defer server.Close()
+
+	client, _ := chargily.NewClient("test_api_key", "test", chargily.WithMiddleware(redirectTo(server)))
+
+	var buf bytes.Buffer
+	err := export.Customers(context.Background(), client, &buf, export.JSONL, export.CreatedBefore(time.Unix(2000, 0)))
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 1)
+
+	var customer map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &customer))
+	assert.Equal(t, "cus_old", customer["id"])
+	assert.Equal(t, []string{"created_before=2000", "created_before=2000&page=2"}, queries)
+}
+
+func TestExportCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a slow page, until the request is canceled
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client, _ := chargily.NewClient("test_api_key", "test", chargily.WithMiddleware(redirectTo(server)))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := export.Customers(ctx, client, &bytes.Buffer{}, export.CSV)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestExportUnsupportedFormat(t *testing.T) {
+	client, _ := chargily.NewClient("test_api_key", "test")
+
+	err := export.Customers(context.Background(), client, &bytes.Buffer{}, export.Format("xml"))
+	assert.EqualError(t, err, `unsupported export format: "xml"`)
+}
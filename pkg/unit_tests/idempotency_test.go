@@ -0,0 +1,91 @@
+package unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRequestIdempotencyKey(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key-1", r.Header.Get("Idempotency-Key"))
+		n := atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		json.NewEncoder(w).Encode(map[string]int32{"call": n})
+	}))
+	defer server.Close()
+
+	rs := utils.NewRequestSender("test_api_key", utils.WithIdempotencyCache(utils.NewIdempotencyCache(time.Minute)))
+	body := map[string]string{"name": "customer"}
+
+	// concurrent calls with the same key share the first response
+	var wg sync.WaitGroup
+	results := make([]map[string]int32, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := rs.SendRequest("POST", server.URL, body, &results[i], utils.WithIdempotencyKey("key-1"))
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, result := range results {
+		assert.Equal(t, int32(1), result["call"])
+	}
+
+	// the same key with other parameters is rejected
+	var result map[string]int32
+	err := rs.SendRequest("POST", server.URL, map[string]string{"name": "other"}, &result, utils.WithIdempotencyKey("key-1"))
+	assert.ErrorIs(t, err, utils.ErrIdempotencyKeyReused)
+}
+
+func TestIdempotencyCacheWaitCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	rs := utils.NewRequestSender("test_api_key", utils.WithIdempotencyCache(utils.NewIdempotencyCache(time.Minute)))
+	body := map[string]string{"name": "customer"}
+
+	first := make(chan error, 1)
+	go func() {
+		first <- rs.SendRequest("POST", server.URL, body, nil, utils.WithIdempotencyKey("key-1"))
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// the call waiting for the first one gives up with its own context
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := rs.SendRequest("POST", server.URL, body, nil, utils.WithIdempotencyKey("key-1"), utils.WithContext(ctx))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	select {
+	case err := <-first:
+		t.Fatalf("the first call returned early: %v", err)
+	default:
+	}
+}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	key := utils.NewIdempotencyKey()
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, key)
+	assert.NotEqual(t, key, utils.NewIdempotencyKey())
+}
@@ -0,0 +1,162 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/importer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImporterValidationAndResume(t *testing.T) {
+	client, err := chargily.NewClient("test-api-key", "test")
+	assert.NoError(t, err)
+
+	input := strings.Join([]string{
+		"name,email,address.city,metadata.order_id",
+		"Alice,alice@example.com,Algiers,o-1",
+		",missing-name@example.com,,",
+		"Bob,not-an-email,,",
+	}, "\n")
+
+	// the first row was created by a previous run
+	previous, err := importer.LoadResults(strings.NewReader("row,status,id,price_ids,error\n1,created,cus_1,,\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "cus_1", previous[1].ID)
+
+	var results bytes.Buffer
+	im := importer.New(client, importer.Workers(2), importer.Resume(previous))
+	summary, err := im.Customers(context.Background(), strings.NewReader(input), importer.CSV, &results)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &importer.Summary{Total: 3, Skipped: 1, Invalid: 2}, summary)
+
+	loaded, err := importer.LoadResults(&results)
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 3)
+	assert.Equal(t, importer.StatusCreated, loaded[1].Status)
+	assert.Equal(t, importer.StatusInvalid, loaded[2].Status)
+	assert.Equal(t, "name is required", loaded[2].Error)
+	assert.Equal(t, importer.StatusInvalid, loaded[3].Status)
+	assert.Contains(t, loaded[3].Error, "invalid email")
+}
+
+func TestImporterProductsJSONL(t *testing.T) {
+	client, err := chargily.NewClient("test-api-key", "test")
+	assert.NoError(t, err)
+
+	input := `{"name":"Shirt","prices":[{"amount":0,"currency":"dzd"}]}
+{"name":"Hat","prices":[{"amount":1500,"currency":"btc"}]}
+{"nmae":"typo"}
+`
+	var results bytes.Buffer
+	summary, err := importer.New(client).Products(context.Background(), strings.NewReader(input), importer.JSONL, &results)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, summary.Invalid)
+
+	loaded, err := importer.LoadResults(&results)
+	assert.NoError(t, err)
+	assert.Equal(t, "price 1: amount must be positive", loaded[1].Error)
+	assert.Equal(t, `price 1: unsupported currency "btc"`, loaded[2].Error)
+	assert.Contains(t, loaded[3].Error, "unknown field")
+}
+
+func TestImporterCreatesRecords(t *testing.T) {
+	var mu sync.Mutex
+	var created []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		created = append(created, body)
+		mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/test/api/v2/customers":
+			json.NewEncoder(w).Encode(map[string]any{"id": "cus_" + strings.ToLower(body["name"].(string))})
+		case r.URL.Path == "/test/api/v2/products":
+			json.NewEncoder(w).Encode(map[string]any{"id": "prod_1"})
+		case body["amount"] == 1500.0:
+			json.NewEncoder(w).Encode(map[string]any{"id": "price_1"})
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]any{"message": "The amount is too high."})
+		}
+	}))
+	defer server.Close()
+
+	client, err := chargily.NewClient("test-api-key", "test", chargily.WithMiddleware(redirectTo(server)))
+	assert.NoError(t, err)
+
+	input := "name,email,address.city,metadata.order_id\nAlice,alice@example.com,Algiers,o-1\nBob,bob@example.com,,\n"
+	var results bytes.Buffer
+	summary, err := importer.New(client, importer.Workers(1)).Customers(context.Background(), strings.NewReader(input), importer.CSV, &results)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &importer.Summary{Total: 2, Created: 2}, summary)
+	assert.Equal(t, "row,status,id,price_ids,error\n1,created,cus_alice,,\n2,created,cus_bob,,\n", results.String())
+	assert.Equal(t, "Algiers", created[0]["address"].(map[string]any)["city"])
+	assert.Equal(t, "o-1", created[0]["metadata"].(map[string]any)["order_id"])
+
+	// the product and its first price are created, the second price is rejected
+	created = nil
+	results.Reset()
+	summary, err = importer.New(client).Products(context.Background(),
+		strings.NewReader(`{"name":"Shirt","prices":[{"amount":1500,"currency":"dzd"},{"amount":99999999,"currency":"dzd"}]}`),
+		importer.JSONL, &results)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &importer.Summary{Total: 1, Failed: 1}, summary)
+	loaded, err := importer.LoadResults(&results)
+	assert.NoError(t, err)
+	assert.Equal(t, importer.StatusFailed, loaded[1].Status)
+	assert.Equal(t, "prod_1", loaded[1].ID)
+	assert.Equal(t, []string{"price_1"}, loaded[1].PriceIDs)
+	assert.Equal(t, "prod_1", created[1]["product_id"])
+}
+
+func TestImporterIdempotencyKeys(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.URL.Path] = append(keys[r.URL.Path], r.Header.Get("Idempotency-Key"))
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"id": "obj_1"})
+	}))
+	defer server.Close()
+
+	client, err := chargily.NewClient("test-api-key", "test", chargily.WithMiddleware(redirectTo(server)))
+	assert.NoError(t, err)
+
+	input := `{"name":"Shirt","prices":[{"amount":1500,"currency":"dzd"},{"amount":2500,"currency":"dzd"}]}
+{"name":"Hat","prices":[{"amount":1000,"currency":"dzd"}]}
+`
+	importProducts := func(source string) {
+		_, err := importer.New(client, importer.Workers(1), importer.Source(source)).Products(context.Background(),
+			strings.NewReader(input), importer.JSONL, &bytes.Buffer{})
+		assert.NoError(t, err)
+	}
+
+	// every request of a row has its own key
+	importProducts("products.jsonl")
+	products, prices := keys["/test/api/v2/products"], keys["/test/api/v2/prices"]
+	assert.Len(t, products, 2)
+	assert.Len(t, prices, 3)
+	assert.NotEqual(t, products[0], products[1])
+	assert.Equal(t, []string{products[0] + "-price-1", products[0] + "-price-2", products[1] + "-price-1"}, prices)
+
+	// the same input gets the same keys when imported again, another one gets others
+	importProducts("products.jsonl")
+	assert.Equal(t, products, keys["/test/api/v2/products"][2:])
+	importProducts("other.jsonl")
+	assert.NotContains(t, products, keys["/test/api/v2/products"][4])
+}
@@ -0,0 +1,215 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/Chargily/chargily-pay-go/pkg/webhookqueue"
+	"github.com/stretchr/testify/assert"
+)
+
+// postEvent sends a signed payload to a webhook handler, with the extra headers
+func postEvent(handler http.Handler, payload []byte, apiKey string, headers map[string]string) int {
+	req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(payload))
+	req.Header.Set("signature", signPayload(payload, apiKey))
+	req.Header.Set("Cookie", "session=secret")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestEventJournalRecordsOutcomes(t *testing.T) {
+	journal := chargily.NewFileEventJournal(filepath.Join(t.TempDir(), "events.jsonl"))
+	client, _ := chargily.NewClient("test_key", "test",
+		chargily.WithEventJournal(journal),
+		chargily.WithLivemodeCheck(chargily.RejectLivemode, func(chargily.LivemodeMismatch) {}))
+
+	handler := client.Webhook.Handler(func(eventType string, event models.WebhookEvent) {
+		if event.ID == "evt_bug" {
+			panic("nil order")
+		}
+	})
+
+	ok := []byte(`{"id":"evt_ok","type":"checkout.paid","livemode":"false"}`)
+	assert.Equal(t, http.StatusOK, postEvent(handler, ok, "test_key", nil))
+	live := []byte(`{"id":"evt_live","type":"checkout.paid","livemode":"true"}`)
+	assert.Equal(t, http.StatusBadRequest, postEvent(handler, live, "test_key", nil))
+	bug := []byte(`{"id":"evt_bug","type":"checkout.failed","livemode":"false"}`)
+	assert.Panics(t, func() { postEvent(handler, bug, "test_key", nil) })
+	// not verified, not recorded
+	assert.Equal(t, http.StatusForbidden, postEvent(handler, ok, "other_key", nil))
+
+	ctx := context.Background()
+	records, err := journal.List(ctx, chargily.EventFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+
+	record, err := journal.Get(ctx, "evt_ok")
+	assert.NoError(t, err)
+	assert.Equal(t, chargily.EventHandled, record.Outcome)
+	assert.Equal(t, string(ok), record.Payload)
+	assert.Equal(t, signPayload(ok, "test_key"), record.Signature)
+	assert.Empty(t, record.Headers.Get("Cookie"))
+
+	failed, err := journal.List(ctx, chargily.EventFilter{Outcome: chargily.EventFailed})
+	assert.NoError(t, err)
+	assert.Len(t, failed, 1)
+	assert.Equal(t, "evt_bug", failed[0].EventID)
+	assert.Equal(t, "panic: nil order", failed[0].Error)
+
+	record, _ = journal.Get(ctx, "evt_live")
+	assert.Equal(t, chargily.EventRejected, record.Outcome)
+
+	recent, err := journal.List(ctx, chargily.EventFilter{Since: time.Now().Add(time.Hour)})
+	assert.NoError(t, err)
+	assert.Empty(t, recent)
+	_, err = journal.Get(ctx, "evt_unknown")
+	assert.ErrorIs(t, err, chargily.ErrEventNotFound)
+}
+
+func TestSignedReplays(t *testing.T) {
+	journal := chargily.NewFileEventJournal(filepath.Join(t.TempDir(), "events.jsonl"))
+	client, _ := chargily.NewClient("test_key", "test",
+		chargily.WithEventJournal(journal),
+		chargily.WithReplayProtection(time.Minute, chargily.NewMemoryEventStore(time.Minute)))
+
+	var handled int
+	handler := client.Webhook.Handler(func(eventType string, event models.WebhookEvent) { handled++ })
+
+	// an old event, refused as stale
+	payload := eventPayload("evt_1", time.Now().Add(-time.Hour))
+	assert.Equal(t, http.StatusInternalServerError, postEvent(handler, payload, "test_key", nil))
+
+	replay, err := client.Webhook.SignReplay(payload, time.Now())
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusOK, postEvent(handler, payload, "test_key", map[string]string{chargily.ReplayHeader: replay}))
+	}
+	assert.Equal(t, 2, handled)
+
+	record, err := journal.Get(context.Background(), "evt_1")
+	assert.NoError(t, err)
+	assert.True(t, record.Replayed)
+	assert.Equal(t, chargily.EventHandled, record.Outcome)
+
+	// a replay signed with another key, or too long ago, is refused
+	other, _ := chargily.NewClient("other_key", "test")
+	forged, _ := other.Webhook.SignReplay(payload, time.Now())
+	assert.Equal(t, http.StatusForbidden, postEvent(handler, payload, "test_key", map[string]string{chargily.ReplayHeader: forged}))
+	expired, _ := client.Webhook.SignReplay(payload, time.Now().Add(-time.Hour))
+	assert.Equal(t, http.StatusForbidden, postEvent(handler, payload, "test_key", map[string]string{chargily.ReplayHeader: expired}))
+	assert.Equal(t, 2, handled)
+}
+
+func TestProcessorJournal(t *testing.T) {
+	journal := chargily.NewFileEventJournal(filepath.Join(t.TempDir(), "events.jsonl"))
+	client, _ := chargily.NewClient("test_key", "test", chargily.WithEventJournal(journal))
+
+	processor := webhookqueue.New(webhookqueue.NewMemoryQueue(10),
+		func(ctx context.Context, event models.WebhookEvent) error {
+			if event.ID == "evt_bad" {
+				return errors.New("erp rejected the order")
+			}
+			return nil
+		},
+		webhookqueue.Retries(2, time.Millisecond),
+		webhookqueue.Journal(journal))
+	handler := processor.Handler(client.Webhook)
+
+	for _, id := range []string{"evt_good", "evt_bad"} {
+		payload := []byte(`{"id":"` + id + `","type":"checkout.paid","livemode":"false"}`)
+		assert.Equal(t, http.StatusOK, postEvent(handler, payload, "test_key", nil))
+	}
+	record, _ := journal.Get(context.Background(), "evt_good")
+	assert.Equal(t, chargily.EventQueued, record.Outcome)
+
+	processor.Start()
+	assert.NoError(t, processor.Shutdown(context.Background()))
+
+	record, _ = journal.Get(context.Background(), "evt_good")
+	assert.Equal(t, chargily.EventHandled, record.Outcome)
+	record, _ = journal.Get(context.Background(), "evt_bad")
+	assert.Equal(t, chargily.EventFailed, record.Outcome)
+	assert.Equal(t, "erp rejected the order", record.Error)
+}
+
+func TestFileEventJournalCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	journal := chargily.NewFileEventJournal(path)
+	ctx := context.Background()
+	receivedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// every event is recorded again and again, the replaced records are compacted away
+	for i := 0; i < 1200; i++ {
+		id := fmt.Sprintf("evt_%d", i%10)
+		record := chargily.EventRecord{EventID: id, ReceivedAt: receivedAt.Add(time.Duration(i%10) * time.Second),
+			Outcome: chargily.EventReceived, Error: strconv.Itoa(i)}
+		assert.NoError(t, journal.Record(ctx, record))
+	}
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Less(t, bytes.Count(raw, []byte("\n")), 1000)
+
+	record, err := journal.Get(ctx, "evt_3")
+	assert.NoError(t, err)
+	assert.Equal(t, "1193", record.Error)
+	records, err := journal.List(ctx, chargily.EventFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 10)
+	assert.Equal(t, "evt_0", records[0].EventID)
+	assert.Equal(t, "1190", records[0].Error)
+
+	// the records appended by another process are found
+	other := chargily.NewFileEventJournal(path)
+	assert.NoError(t, other.Record(ctx, chargily.EventRecord{EventID: "evt_3", Outcome: chargily.EventHandled}))
+	record, err = journal.Get(ctx, "evt_3")
+	assert.NoError(t, err)
+	assert.Equal(t, chargily.EventHandled, record.Outcome)
+	_, err = journal.Get(ctx, "evt_missing")
+	assert.ErrorIs(t, err, chargily.ErrEventNotFound)
+}
+
+func TestFileEventJournalPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	ctx := context.Background()
+	journal := chargily.NewFileEventJournal(path)
+	assert.NoError(t, journal.Record(ctx, chargily.EventRecord{EventID: "evt_1", Outcome: chargily.EventHandled}))
+
+	// the process stopped while writing a record
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	assert.NoError(t, err)
+	_, err = file.WriteString(`{"event_id":"evt_2","outc`)
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	// the part of the line is dropped by the next record
+	journal = chargily.NewFileEventJournal(path)
+	assert.NoError(t, journal.Record(ctx, chargily.EventRecord{EventID: "evt_2", Outcome: chargily.EventQueued}))
+	assert.NoError(t, journal.Record(ctx, chargily.EventRecord{EventID: "evt_3", Outcome: chargily.EventHandled}))
+
+	records, err := journal.List(ctx, chargily.EventFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+	record, err := journal.Get(ctx, "evt_2")
+	assert.NoError(t, err)
+	assert.Equal(t, chargily.EventQueued, record.Outcome)
+
+	// and by another instance
+	records, err = chargily.NewFileEventJournal(path).List(ctx, chargily.EventFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+}
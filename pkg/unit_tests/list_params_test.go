@@ -0,0 +1,106 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCheckoutsParamsQuery(t *testing.T) {
+	params := &models.ListCheckoutsParams{
+		ListParams:    models.ListParams{Page: 2, PerPage: 25},
+		CreatedRange:  models.CreatedRange{CreatedAfter: time.Unix(1700000000, 0)},
+		Status:        "paid",
+		PaymentLinkID: "plink_1",
+	}
+	assert.Equal(t, "created_after=1700000000&page=2&payment_link_id=plink_1&per_page=25&status=paid", params.Query().Encode())
+
+	var none *models.ListCheckoutsParams
+	assert.Empty(t, none.Query())
+	assert.True(t, none.Match(models.Checkout{}))
+}
+
+func TestListParamsMatch(t *testing.T) {
+	link := "plink_1"
+	params := &models.ListCheckoutsParams{
+		CreatedRange:  models.CreatedRange{CreatedAfter: time.Unix(100, 0), CreatedBefore: time.Unix(200, 0)},
+		Status:        "paid",
+		PaymentLinkID: link,
+	}
+	assert.True(t, params.Match(models.Checkout{Status: "paid", PaymentLinkID: &link, CreatedAt: models.UnixTimestamp(100)}))
+	assert.False(t, params.Match(models.Checkout{Status: "paid", PaymentLinkID: &link, CreatedAt: models.UnixTimestamp(200)}))
+	assert.False(t, params.Match(models.Checkout{Status: "pending", PaymentLinkID: &link, CreatedAt: models.UnixTimestamp(150)}))
+	assert.False(t, params.Match(models.Checkout{Status: "paid", CreatedAt: models.UnixTimestamp(150)}))
+
+	active := true
+	links := &models.ListPaymentLinksParams{Active: &active}
+	assert.True(t, links.Match(models.PaymentLink{Active: true}))
+	assert.False(t, links.Match(models.PaymentLink{Active: false}))
+
+	customers := &models.ListCustomersParams{Email: "Alice@example.com"}
+	assert.True(t, customers.Match(models.Customer{Email: "alice@example.com"}))
+	assert.False(t, customers.Match(models.Customer{Email: "bob@example.com"}))
+}
+
+func TestListFiltersEveryPage(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+
+		// the API ignores the filters and only carries the page in its next_page_url
+		page := models.RetrieveAll[models.Checkout]{
+			Data: []models.Checkout{{ID: "chk_1", Status: "paid"}, {ID: "chk_2", Status: "pending"}},
+		}
+		if r.URL.Query().Get("page") == "" {
+			next := chargily.TestAPIBaseUrl + "checkouts?page=2"
+			page.NextPageURL = &next
+		} else {
+			page.Data = []models.Checkout{{ID: "chk_3", Status: "paid"}}
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client, _ := chargily.NewClient("test_api_key", "test", chargily.WithMiddleware(redirectTo(server)))
+
+	pager := client.Checkouts.List(&models.ListCheckoutsParams{Status: "paid", ListParams: models.ListParams{PerPage: 2}})
+
+	var ids []string
+	err := pager.ForEach(func(checkout models.Checkout) error {
+		ids = append(ids, checkout.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"chk_1", "chk_3"}, ids)
+	assert.Equal(t, []string{"per_page=2&status=paid", "page=2&per_page=2&status=paid"}, queries)
+}
+
+func TestStaticPager(t *testing.T) {
+	pager := chargily.StaticPager(
+		&models.RetrieveAll[models.Customer]{Data: []models.Customer{{ID: "cus_1"}, {ID: "cus_2"}}},
+		&models.RetrieveAll[models.Customer]{Data: []models.Customer{{ID: "cus_3"}}},
+	)
+
+	page, err := pager.Next()
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+
+	// ForEach goes on from the pages not returned yet
+	var ids []string
+	assert.NoError(t, pager.ForEach(func(customer models.Customer) error {
+		ids = append(ids, customer.ID)
+		return nil
+	}))
+	assert.Equal(t, []string{"cus_3"}, ids)
+
+	page, err = pager.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, page)
+}
@@ -0,0 +1,153 @@
+package unit_tests
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLivemodeResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/test/api/v2/checkouts/chk_live":
+			w.Write([]byte(`{"id":"chk_live","entity":"checkout","livemode":true}`))
+		case "/test/api/v2/checkouts/chk_test":
+			w.Write([]byte(`{"id":"chk_test","entity":"checkout","livemode":false}`))
+		default:
+			w.Write([]byte(`{"entity":"balance"}`))
+		}
+	}))
+	defer server.Close()
+
+	var flagged []chargily.LivemodeMismatch
+	flag := func(mismatch chargily.LivemodeMismatch) {
+		flagged = append(flagged, mismatch)
+	}
+
+	// flagged, the response is still returned
+	client, _ := chargily.NewClient("test_key", "test",
+		chargily.WithLivemodeCheck(chargily.FlagLivemode, flag), chargily.WithMiddleware(redirectTo(server)))
+	checkout, err := client.Checkouts.Get("chk_live")
+	assert.NoError(t, err)
+	assert.Equal(t, "chk_live", checkout.ID)
+
+	// rejected
+	client, _ = chargily.NewClient("test_key", "test",
+		chargily.WithLivemodeCheck(chargily.RejectLivemode, flag), chargily.WithMiddleware(redirectTo(server)))
+	_, err = client.Checkouts.Get("chk_live")
+	assert.ErrorIs(t, err, chargily.ErrLivemodeMismatch)
+	_, err = client.Checkouts.Get("chk_test")
+	assert.NoError(t, err)
+	// the responses without a livemode are not checked
+	_, err = client.Balance.Get()
+	assert.NoError(t, err)
+
+	assert.Len(t, flagged, 2)
+	assert.Equal(t, chargily.LivemodeMismatch{Live: false, Resource: "checkouts", Operation: "get", ObjectID: "chk_live"}, flagged[1])
+}
+
+func TestLivemodeLists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"current_page":1,"data":[
+			{"id":"chk_1","livemode":false},
+			{"id":"chk_2","livemode":true},
+			{"id":"chk_3"},
+			{"id":"chk_4","livemode":true}
+		]}`))
+	}))
+	defer server.Close()
+
+	var flagged []chargily.LivemodeMismatch
+	flag := func(mismatch chargily.LivemodeMismatch) {
+		flagged = append(flagged, mismatch)
+	}
+
+	// every object of the page is flagged, the page is still returned
+	client, _ := chargily.NewClient("test_key", "test",
+		chargily.WithLivemodeCheck(chargily.FlagLivemode, flag), chargily.WithMiddleware(redirectTo(server)))
+	checkouts, err := client.Checkouts.GetAll()
+	assert.NoError(t, err)
+	assert.Len(t, checkouts.Data, 4)
+	assert.Equal(t, []chargily.LivemodeMismatch{
+		{Live: false, Resource: "checkouts", Operation: "list", ObjectID: "chk_2"},
+		{Live: false, Resource: "checkouts", Operation: "list", ObjectID: "chk_4"},
+	}, flagged)
+
+	// rejected on the first one
+	flagged = nil
+	client, _ = chargily.NewClient("test_key", "test",
+		chargily.WithLivemodeCheck(chargily.RejectLivemode, flag), chargily.WithMiddleware(redirectTo(server)))
+	_, err = client.Checkouts.GetAll()
+	assert.ErrorIs(t, err, chargily.ErrLivemodeMismatch)
+	assert.Len(t, flagged, 1)
+}
+
+func TestLivemodeWebhooks(t *testing.T) {
+	var flagged []chargily.LivemodeMismatch
+	client, _ := chargily.NewClient("prod_key", "prod", chargily.WithLivemodeCheck(chargily.RejectLivemode,
+		func(mismatch chargily.LivemodeMismatch) {
+			flagged = append(flagged, mismatch)
+		}))
+
+	var handled []string
+	handler := client.Webhook.Handler(func(eventType string, event models.WebhookEvent) {
+		handled = append(handled, event.ID)
+	})
+	post := func(payload string) int {
+		req := httptest.NewRequest("POST", "/webhook", bytes.NewReader([]byte(payload)))
+		req.Header.Set("signature", signPayload([]byte(payload), "prod_key"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, post(`{"id":"evt_live","type":"checkout.paid","livemode":"true","data":{"id":"chk_1"}}`))
+	// a test mode event must not fulfill a real order
+	assert.Equal(t, http.StatusBadRequest, post(`{"id":"evt_test","type":"checkout.paid","livemode":"false","data":{"id":"chk_2"}}`))
+
+	assert.Equal(t, []string{"evt_live"}, handled)
+	assert.Equal(t, []chargily.LivemodeMismatch{
+		{Live: true, ObjectID: "chk_2", EventID: "evt_test", EventType: "checkout.paid"},
+	}, flagged)
+	assert.EqualError(t, flagged[0], "livemode mismatch: test event evt_test (checkout.paid) received in live mode")
+}
+
+func TestLivemodeLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"chk_live","entity":"checkout","livemode":true}`))
+	}))
+	defer server.Close()
+
+	// nothing is logged to the default logger without WithLogger
+	var defaults bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&defaults, nil)))
+	defer slog.SetDefault(previous)
+
+	client, _ := chargily.NewClient("test_key", "test", chargily.WithMiddleware(redirectTo(server)))
+	_, err := client.Checkouts.Get("chk_live")
+	assert.NoError(t, err)
+	assert.Empty(t, defaults.String())
+
+	// the mismatches are still rejected
+	client, _ = chargily.NewClient("test_key", "test",
+		chargily.WithLivemodeCheck(chargily.RejectLivemode, nil), chargily.WithMiddleware(redirectTo(server)))
+	_, err = client.Checkouts.Get("chk_live")
+	assert.ErrorIs(t, err, chargily.ErrLivemodeMismatch)
+	assert.Empty(t, defaults.String())
+
+	// they are logged to the logger of the client
+	var logs bytes.Buffer
+	client, _ = chargily.NewClient("test_key", "test",
+		chargily.WithLogger(slog.New(slog.NewTextHandler(&logs, nil))), chargily.WithMiddleware(redirectTo(server)))
+	_, err = client.Checkouts.Get("chk_live")
+	assert.NoError(t, err)
+	assert.Contains(t, logs.String(), "livemode doesn't match")
+	assert.Empty(t, defaults.String())
+}
@@ -0,0 +1,91 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactor(t *testing.T) {
+	r := utils.NewRedactor([]string{"secret-key"}, []string{"tax_id"})
+
+	assert.Equal(t, "Bearer [REDACTED]", r.Redact("Bearer secret-key"))
+	assert.Equal(t, "contact [EMAIL] or [PHONE] or [PHONE]", r.Redact("contact alice@example.com or +213 555 12 34 56 or 0555123456"))
+	assert.Equal(t, "amount 1700000000 is invalid", r.Redact("amount 1700000000 is invalid"))
+	assert.JSONEq(t,
+		`{"metadata":{"tax_id":"[REDACTED]","order":"o-1"},"errors":{"metadata.tax_id":"[REDACTED]"}}`,
+		r.Redact(`{"metadata":{"tax_id":"123","order":"o-1"},"errors":{"metadata.tax_id":["123 is invalid"]}}`),
+	)
+}
+
+func TestClientLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_1")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(utils.GeneralError{
+			Message: "The email has already been taken.",
+			Errors:  map[string][]string{"email": {"alice@example.com is taken"}},
+		})
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client, err := chargily.NewClient("secret-key", "test",
+		chargily.WithLogger(logger),
+		chargily.WithMiddleware(redirectTo(server)),
+	)
+	assert.NoError(t, err)
+
+	_, err = client.Customers.Create(&models.CreateCustomerParams{Email: "alice@example.com"})
+	assert.Error(t, err)
+	assert.NotContains(t, client.Redact(err.Error()), "alice@example.com")
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(logs.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "POST", record["method"])
+	assert.Equal(t, "/test/api/v2/customers", record["path"])
+	assert.Equal(t, float64(422), record["status"])
+	assert.Equal(t, "req_1", record["request_id"])
+	assert.Equal(t, "customers", record["resource"])
+	assert.Equal(t, "[REDACTED]", record["headers"].(map[string]any)["Authorization"])
+	assert.NotContains(t, logs.String(), "alice@example.com")
+	assert.NotContains(t, logs.String(), "secret-key")
+}
+
+func TestClientLoggerRedactsCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"invalid api key ` + r.Header.Get("Authorization") + `"}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	// the key sent comes from the credentials, not from the key given to NewClient
+	client, err := chargily.NewClient("", "test",
+		chargily.WithCredentials(chargily.CredentialsFunc(func(ctx context.Context) (string, error) {
+			return "rotated-key", nil
+		})),
+		chargily.WithLogger(logger),
+		chargily.WithMiddleware(redirectTo(server)),
+	)
+	assert.NoError(t, err)
+
+	_, err = client.Balance.Get()
+	assert.Error(t, err)
+	assert.NotContains(t, logs.String(), "rotated-key")
+	assert.Contains(t, logs.String(), "invalid api key Bearer [REDACTED]")
+}
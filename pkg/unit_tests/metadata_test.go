@@ -0,0 +1,83 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type orderMeta struct {
+	OrderID  string `json:"order_id"`
+	TenantID string `json:"tenant_id,omitempty"`
+	Items    int    `json:"items"`
+}
+
+func TestSetMetadata(t *testing.T) {
+	params := &models.CheckoutParams{Metadata: models.Metadata{"source": "web"}}
+	assert.NoError(t, models.SetMetadata(params, orderMeta{OrderID: "1042", TenantID: "acme", Items: 3}))
+	assert.Equal(t, models.Metadata{"source": "web", "order_id": "1042", "tenant_id": "acme", "items": float64(3)}, params.Metadata)
+
+	// the metadata is left as is when the limits are exceeded
+	err := models.SetMetadata(params, map[string]string{strings.Repeat("k", models.MaxMetadataKeyLength+1): "v"})
+	assert.ErrorIs(t, err, models.ErrInvalidMetadata)
+	err = models.SetMetadata(params, map[string]string{"note": strings.Repeat("v", models.MaxMetadataValueLength)})
+	assert.ErrorIs(t, err, models.ErrInvalidMetadata)
+	assert.Len(t, params.Metadata, 4)
+
+	assert.ErrorIs(t, models.SetMetadata(params, []string{"not", "an", "object"}), models.ErrInvalidMetadata)
+
+	tooMany := models.Metadata{}
+	for i := 0; i <= models.MaxMetadataKeys; i++ {
+		tooMany[fmt.Sprintf("key_%d", i)] = i
+	}
+	assert.ErrorIs(t, tooMany.Validate(), models.ErrInvalidMetadata)
+}
+
+func TestGetMetadata(t *testing.T) {
+	var checkout models.Checkout
+	assert.NoError(t, json.Unmarshal([]byte(`{"metadata": {"order_id": "1042", "items": 3, "other": true}}`), &checkout))
+
+	meta, err := models.GetMetadata[orderMeta](&checkout)
+	assert.NoError(t, err)
+	assert.Equal(t, orderMeta{OrderID: "1042", Items: 3}, meta)
+
+	// a value of another type
+	_, err = models.GetMetadata[struct {
+		Items string `json:"items"`
+	}](&checkout)
+	assert.ErrorIs(t, err, models.ErrInvalidMetadata)
+}
+
+func TestMetadataFromWebhooks(t *testing.T) {
+	for raw, expected := range map[string]models.Metadata{
+		`null`:                       nil,
+		`""`:                         nil,
+		`[]`:                         nil,
+		`{"order_id": "1042"}`:       {"order_id": "1042"},
+		`"{\"order_id\": \"1042\"}"`: {"order_id": "1042"},
+		`"[]"`:                       nil,
+	} {
+		var event models.WebhookEvent
+		assert.NoError(t, json.Unmarshal([]byte(`{"id": "evt_1", "data": {"metadata": `+raw+`}}`), &event), raw)
+		assert.Equal(t, expected, event.Data.Metadata, raw)
+
+		meta, err := models.GetMetadata[orderMeta](&event)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, expected.Get("order_id"), meta.OrderID, raw)
+	}
+
+	var metadata models.Metadata
+	assert.NoError(t, json.Unmarshal([]byte(`{"order_id": "1042", "tier": 2, "vip": true, "tags": ["a"]}`), &metadata))
+	assert.Equal(t, "1042", metadata.Get("order_id"))
+	assert.Equal(t, "2", metadata.Get("tier"))
+	assert.Equal(t, "true", metadata.Get("vip"))
+	assert.Equal(t, `["a"]`, metadata.Get("tags"))
+	assert.Equal(t, "", metadata.Get("missing"))
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`[1, 2]`), &metadata), models.ErrInvalidMetadata)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`"not json"`), &metadata), models.ErrInvalidMetadata)
+}
@@ -0,0 +1,76 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRequestMiddlewares(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "outer,inner", r.Header.Get("X-Trace"))
+		json.NewEncoder(w).Encode(map[string]string{"response": "success"})
+	}))
+	defer server.Close()
+
+	var order []string
+	tag := func(name string) utils.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return utils.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				if trace := req.Header.Get("X-Trace"); trace != "" {
+					name = trace + "," + name
+				}
+				req.Header.Set("X-Trace", name)
+				res, err := next.RoundTrip(req)
+				order = append(order, name+" done")
+				return res, err
+			})
+		}
+	}
+
+	rs := utils.NewRequestSender("test_api_key", utils.WithMiddleware(tag("outer"), tag("inner")))
+
+	var result map[string]string
+	err := rs.SendRequest("GET", server.URL, nil, &result)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "success", result["response"])
+	assert.Equal(t, []string{"outer", "inner", "outer,inner done", "outer done"}, order)
+}
+
+func TestSendRequestMiddlewareFaultInjection(t *testing.T) {
+	errInjected := errors.New("injected failure")
+	fail := func(next http.RoundTripper) http.RoundTripper {
+		return utils.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errInjected
+		})
+	}
+
+	rs := utils.NewRequestSender("test_api_key", utils.WithMiddleware(fail))
+
+	var result map[string]string
+	err := rs.SendRequest("GET", "https://example.invalid", nil, &result)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "injected failure")
+}
+
+// redirectTo sends every request of the client to the test server
+func redirectTo(server *httptest.Server) chargily.Middleware {
+	target, _ := url.Parse(server.URL)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return chargily.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			return next.RoundTrip(req)
+		})
+	}
+}
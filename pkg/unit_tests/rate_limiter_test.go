@@ -0,0 +1,91 @@
+package unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := utils.NewRateLimiter(20, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, limiter.Wait(ctx))
+	}
+
+	// the burst goes through at once, the two other requests wait 50ms each
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 90*time.Millisecond)
+	assert.Less(t, elapsed, time.Second)
+}
+
+func TestRateLimiterWaitCanceled(t *testing.T) {
+	limiter := utils.NewRateLimiter(1, 1)
+	limiter.Pause(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSendRequestRetriesAfterTooManyRequests(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"response": "success"})
+	}))
+	defer server.Close()
+
+	rs := utils.NewRequestSender("test_api_key", utils.WithRateLimiter(utils.NewRateLimiter(100, 10)))
+
+	start := time.Now()
+	var result map[string]string
+	err := rs.SendRequest("GET", server.URL, nil, &result)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "success", result["response"])
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestNewRateLimiterRejectsInvalidRate(t *testing.T) {
+	assert.PanicsWithValue(t, "utils: NewRateLimiter: rps must be a positive number, got 0", func() { utils.NewRateLimiter(0, 1) })
+	assert.Panics(t, func() { utils.NewRateLimiter(-1, 1) })
+	assert.Panics(t, func() { utils.NewRateLimiter(math.NaN(), 1) })
+}
+
+func TestSendRequestWithCallerContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"response": "success"})
+	}))
+	defer server.Close()
+
+	// the first request takes the only token, the second one waits for a minute
+	rs := utils.NewRequestSender("test_api_key", utils.WithRateLimiter(utils.NewRateLimiter(1.0/60, 1)), utils.WithTimeout(time.Minute))
+	var result map[string]string
+	assert.NoError(t, rs.SendRequest("GET", server.URL, nil, &result))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := rs.SendRequest("GET", server.URL, nil, &result, utils.WithContext(ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+}
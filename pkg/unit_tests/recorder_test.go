@@ -0,0 +1,81 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargilytest/recorder"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorderReplayCassette(t *testing.T) {
+	rec, err := recorder.New("testdata/cassettes/customers.yaml", recorder.ModeReplay)
+	assert.NoError(t, err)
+
+	client, err := chargily.NewClient("any-api-key", "test", rec.Option())
+	assert.NoError(t, err)
+
+	customer, err := client.Customers.Create(&models.CreateCustomerParams{Name: "Alice", Email: "alice@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "01j8zc8ab6x4d2t3e9p5n7m1qw", customer.ID)
+
+	customer, err = client.Customers.Get(customer.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", customer.Name)
+
+	// every interaction was used already
+	_, err = client.Customers.Get(customer.ID)
+	assert.ErrorIs(t, err, recorder.ErrNoInteraction)
+}
+
+func TestRecorderRecord(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.Product{ID: "prod_1", Name: "Shirt"})
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "products.json")
+	rec, err := recorder.New(path, recorder.ModeAuto)
+	assert.NoError(t, err)
+	assert.Equal(t, recorder.ModeRecord, rec.Mode())
+
+	client, err := chargily.NewClient("secret-api-key", "test", rec.Option(), chargily.WithMiddleware(redirectTo(server)))
+	assert.NoError(t, err)
+
+	product, err := client.Products.Create(&models.CreateProductParams{Name: "Shirt"})
+	assert.NoError(t, err)
+	assert.Equal(t, "prod_1", product.ID)
+	assert.NoError(t, rec.Stop())
+
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(raw), "secret-api-key")
+
+	// the cassette is replayed offline once it exists
+	rec, err = recorder.New(path, recorder.ModeAuto)
+	assert.NoError(t, err)
+	assert.Equal(t, recorder.ModeReplay, rec.Mode())
+
+	client, err = chargily.NewClient("other-api-key", "test", rec.Option())
+	assert.NoError(t, err)
+	product, err = client.Products.Create(&models.CreateProductParams{Name: "Shirt"})
+	assert.NoError(t, err)
+	assert.Equal(t, "prod_1", product.ID)
+}
+
+func TestRecorderRefusesLiveMode(t *testing.T) {
+	rec, err := recorder.New(filepath.Join(t.TempDir(), "live.yaml"), recorder.ModeRecord)
+	assert.NoError(t, err)
+
+	client, err := chargily.NewClient("live-api-key", "prod", rec.Option())
+	assert.NoError(t, err)
+
+	_, err = client.Balance.Get()
+	assert.ErrorIs(t, err, recorder.ErrLiveMode)
+}
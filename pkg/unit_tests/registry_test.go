@@ -0,0 +1,139 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// signPayload computes the signature header of a webhook payload
+func signPayload(payload []byte, apiKey string) string {
+	mac := hmac.New(sha256.New, []byte(apiKey))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestClientRegistryCachesClients(t *testing.T) {
+	var lookups int32
+	keys := chargily.StaticKeys{"acme": {APIKey: "acme_key", Mode: "test"}}
+	provider := chargily.KeyProviderFunc(func(ctx context.Context, tenant string) (chargily.TenantKey, error) {
+		atomic.AddInt32(&lookups, 1)
+		return keys.TenantKey(ctx, tenant)
+	})
+	registry := chargily.NewClientRegistry(provider, chargily.WithTenantRateLimit(10, 5))
+
+	// concurrent first uses share the same creation
+	clients := make([]*chargily.Client, 10)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client, err := registry.Client(context.Background(), "acme")
+			assert.NoError(t, err)
+			clients[i] = client
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&lookups))
+	for _, client := range clients {
+		assert.Same(t, clients[0], client)
+	}
+
+	registry.Invalidate("acme")
+	client, err := registry.Client(context.Background(), "acme")
+	assert.NoError(t, err)
+	assert.NotSame(t, clients[0], client)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&lookups))
+
+	// unknown tenants are not cached
+	_, err = registry.Client(context.Background(), "globex")
+	assert.ErrorIs(t, err, chargily.ErrUnknownTenant)
+	_, err = registry.Client(context.Background(), "globex")
+	assert.ErrorIs(t, err, chargily.ErrUnknownTenant)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&lookups))
+}
+
+func TestClientRegistryWebhooks(t *testing.T) {
+	registry := chargily.NewClientRegistry(chargily.StaticKeys{
+		"acme":   {APIKey: "acme_key", Mode: "test"},
+		"globex": {APIKey: "globex_key", Mode: "test"},
+	})
+
+	var tenants []string
+	mux := http.NewServeMux()
+	mux.Handle("/webhooks/", registry.WebhookHandler(chargily.TenantFromPath("/webhooks/"),
+		func(tenant, eventType string, event models.WebhookEvent) {
+			tenants = append(tenants, tenant+":"+event.ID)
+		}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	payload := []byte(`{"id":"evt_1","type":"checkout.paid","livemode":"false","data":{"id":"chk_1"}}`)
+	post := func(path, apiKey string) int {
+		req, _ := http.NewRequest("POST", server.URL+path, bytes.NewReader(payload))
+		req.Header.Set("signature", signPayload(payload, apiKey))
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, post("/webhooks/acme", "acme_key"))
+	assert.Equal(t, http.StatusOK, post("/webhooks/globex", "globex_key"))
+	// signed with the key of another tenant
+	assert.Equal(t, http.StatusForbidden, post("/webhooks/acme", "globex_key"))
+	assert.Equal(t, http.StatusNotFound, post("/webhooks/initech", "acme_key"))
+	assert.Equal(t, http.StatusNotFound, post("/webhooks/", "acme_key"))
+
+	assert.Equal(t, []string{"acme:evt_1", "globex:evt_1"}, tenants)
+}
+
+func TestTenantFromHeader(t *testing.T) {
+	resolve := chargily.TenantFromHeader("X-Tenant-ID")
+
+	req := httptest.NewRequest("POST", "/webhook", nil)
+	_, err := resolve(req)
+	assert.ErrorIs(t, err, chargily.ErrMissingTenant)
+
+	req.Header.Set("X-Tenant-ID", "acme")
+	tenant, err := resolve(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", tenant)
+}
+
+func TestClientRegistrySharesTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"entity":"balance"}`))
+	}))
+	defer server.Close()
+
+	var authorizations []string
+	transport := redirectTo(server)(chargily.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		authorizations = append(authorizations, req.Header.Get("Authorization"))
+		return http.DefaultTransport.RoundTrip(req)
+	}))
+	registry := chargily.NewClientRegistry(chargily.StaticKeys{
+		"acme":   {APIKey: "acme_key", Mode: "test"},
+		"globex": {APIKey: "globex_key", Mode: "test"},
+	}, chargily.WithRegistryTransport(transport))
+
+	for _, tenant := range []string{"acme", "globex"} {
+		client, err := registry.Client(context.Background(), tenant)
+		assert.NoError(t, err)
+		_, err = client.Balance.Get()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"Bearer acme_key", "Bearer globex_key"}, authorizations)
+}
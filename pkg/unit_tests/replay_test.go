@@ -0,0 +1,136 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// eventPayload returns the payload of an event created at the given time
+func eventPayload(id string, createdAt time.Time) []byte {
+	return []byte(fmt.Sprintf(`{"id":%q,"type":"checkout.paid","livemode":"false","data":{"id":"chk_1"},"created_at":%d}`,
+		id, createdAt.Unix()))
+}
+
+func TestVerifyEventErrors(t *testing.T) {
+	client, _ := chargily.NewClient("test_key", "test",
+		chargily.WithReplayProtection(5*time.Minute, chargily.NewMemoryEventStore(5*time.Minute)))
+	verify := func(payload []byte, apiKey string) error {
+		_, err := client.Webhook.VerifyEvent(context.Background(), payload, signPayload(payload, apiKey))
+		return err
+	}
+
+	fresh := eventPayload("evt_1", time.Now())
+	event, err := client.Webhook.VerifyEvent(context.Background(), fresh, signPayload(fresh, "test_key"))
+	assert.NoError(t, err)
+	assert.Equal(t, "evt_1", event.ID)
+
+	assert.ErrorIs(t, verify(fresh, "test_key"), chargily.ErrReplayedEvent)
+	assert.ErrorIs(t, verify(eventPayload("evt_2", time.Now()), "other_key"), chargily.ErrInvalidSignature)
+	assert.ErrorIs(t, verify(eventPayload("evt_3", time.Now().Add(-time.Hour)), "test_key"), chargily.ErrStaleEvent)
+	assert.ErrorIs(t, verify(eventPayload("evt_4", time.Now().Add(time.Hour)), "test_key"), chargily.ErrStaleEvent)
+	assert.ErrorIs(t, verify([]byte(`{"id":"evt_5"}`), "test_key"), chargily.ErrStaleEvent)
+	assert.ErrorIs(t, verify([]byte(`not json`), "test_key"), chargily.ErrInvalidPayload)
+
+	// without the option any signed event is accepted
+	client, _ = chargily.NewClient("test_key", "test")
+	old := eventPayload("evt_1", time.Now().Add(-24*time.Hour))
+	for i := 0; i < 2; i++ {
+		_, err = client.Webhook.VerifyEvent(context.Background(), old, signPayload(old, "test_key"))
+		assert.NoError(t, err)
+	}
+}
+
+func TestWebhookHandlerReplays(t *testing.T) {
+	journal := chargily.NewFileEventJournal(filepath.Join(t.TempDir(), "events.jsonl"))
+	client, _ := chargily.NewClient("test_key", "test",
+		chargily.WithReplayProtection(time.Minute, chargily.NewMemoryEventStore(time.Minute)),
+		chargily.WithEventJournal(journal))
+
+	var handled []string
+	handler := client.Webhook.Handler(func(eventType string, event models.WebhookEvent) {
+		handled = append(handled, event.ID)
+	})
+	post := func(payload []byte) int {
+		req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(payload))
+		req.Header.Set("signature", signPayload(payload, "test_key"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	payload := eventPayload("evt_1", time.Now())
+	assert.Equal(t, http.StatusOK, post(payload))
+	// the replayed event is acknowledged without being handled again
+	assert.Equal(t, http.StatusOK, post(payload))
+
+	// the stale event fails so Chargily keeps sending it, and is recorded to be replayed
+	stale := eventPayload("evt_2", time.Now().Add(-time.Hour))
+	assert.Equal(t, http.StatusInternalServerError, post(stale))
+	assert.Equal(t, []string{"evt_1"}, handled)
+	record, err := journal.Get(context.Background(), "evt_2")
+	assert.NoError(t, err)
+	assert.Equal(t, chargily.EventRejected, record.Outcome)
+	assert.Contains(t, record.Error, "stale webhook event")
+	assert.Equal(t, string(stale), record.Payload)
+}
+
+func TestWebhookHandlerReleasesFailedEvents(t *testing.T) {
+	client, _ := chargily.NewClient("test_key", "test",
+		chargily.WithReplayProtection(time.Minute, chargily.NewMemoryEventStore(time.Minute)))
+
+	var attempts int
+	handler := client.Webhook.ContextHandler(func(ctx context.Context, event models.WebhookEvent) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+	post := func(payload []byte) int {
+		req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(payload))
+		req.Header.Set("signature", signPayload(payload, "test_key"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// the failed event is handled when sent again, and only acknowledged after that
+	payload := eventPayload("evt_1", time.Now())
+	assert.Equal(t, http.StatusInternalServerError, post(payload))
+	assert.Equal(t, http.StatusOK, post(payload))
+	assert.Equal(t, http.StatusOK, post(payload))
+	assert.Equal(t, 2, attempts)
+}
+
+func TestMemoryEventStoreExpiry(t *testing.T) {
+	store := chargily.NewMemoryEventStore(50 * time.Millisecond)
+	ctx := context.Background()
+
+	seen, err := store.Seen(ctx, "evt_1")
+	assert.NoError(t, err)
+	assert.False(t, seen)
+	seen, _ = store.Seen(ctx, "evt_1")
+	assert.True(t, seen)
+
+	time.Sleep(60 * time.Millisecond)
+	seen, _ = store.Seen(ctx, "evt_1")
+	assert.False(t, seen)
+
+	// a released ID is accepted again
+	assert.NoError(t, store.Release(ctx, "evt_1"))
+	seen, _ = store.Seen(ctx, "evt_1")
+	assert.False(t, seen)
+	seen, _ = store.Seen(ctx, "evt_1")
+	assert.True(t, seen)
+}
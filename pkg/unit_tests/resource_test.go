@@ -0,0 +1,90 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/chargily/utils"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourcePaths(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.EscapedPath())
+		json.NewEncoder(w).Encode(map[string]any{"id": "obj_1", "data": []any{}})
+	}))
+	defer server.Close()
+
+	client, _ := chargily.NewClient("test_api_key", "test", chargily.WithMiddleware(redirectTo(server)))
+
+	_, err := client.Customers.Get("cus_1")
+	assert.NoError(t, err)
+	_, err = client.PaymentLinks.GetItems("plink_1")
+	assert.NoError(t, err)
+	_, err = client.Checkouts.Expire("chk_1")
+	assert.NoError(t, err)
+	assert.NoError(t, client.Products.Delete("prod_1"))
+
+	// an ID can not change the request
+	_, err = client.Prices.Get("../customers")
+	assert.ErrorIs(t, err, chargily.ErrInvalidID)
+	_, err = client.Customers.Get("cus_1?page=2")
+	assert.ErrorIs(t, err, chargily.ErrInvalidID)
+
+	assert.Equal(t, []string{
+		"GET /test/api/v2/customers/cus_1",
+		"GET /test/api/v2/payment-links/plink_1/items",
+		"POST /test/api/v2/checkouts/chk_1/expire",
+		"DELETE /test/api/v2/products/prod_1",
+	}, paths)
+}
+
+func TestResourceMissingID(t *testing.T) {
+	client, _ := chargily.NewClient("test_api_key", "test")
+
+	_, err := client.Customers.Get("")
+	assert.ErrorIs(t, err, chargily.ErrMissingID)
+	_, err = client.Products.Update("", &models.CreateProductParams{Name: "product"})
+	assert.ErrorIs(t, err, chargily.ErrMissingID)
+	_, err = client.Checkouts.GetItems("")
+	assert.ErrorIs(t, err, chargily.ErrMissingID)
+	assert.ErrorIs(t, client.Customers.Delete(""), chargily.ErrMissingID)
+}
+
+func TestURLBuilder(t *testing.T) {
+	u, err := utils.NewURLBuilder(chargily.TestAPIBaseUrl).
+		Path("checkouts").
+		Query(&models.ListParams{Page: 2, PerPage: 20}).
+		Set("status", "paid").
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://pay.chargily.net/test/api/v2/checkouts?page=2&per_page=20&status=paid", u)
+
+	// the segments are escaped
+	u, err = utils.NewURLBuilder(chargily.TestAPIBaseUrl).Path("a b", "c/d").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://pay.chargily.net/test/api/v2/a%20b/c%2Fd", u)
+
+	// nil and empty params add nothing
+	var params *models.ListParams
+	u, err = utils.NewURLBuilder(chargily.TestAPIBaseUrl).Path("customers").Query(params).Query(nil).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "https://pay.chargily.net/test/api/v2/customers", u)
+
+	_, err = utils.NewURLBuilder(chargily.TestAPIBaseUrl).Path("customers").ID("").Build()
+	assert.ErrorIs(t, err, utils.ErrMissingID)
+}
+
+func TestValidateID(t *testing.T) {
+	assert.NoError(t, utils.ValidateID("01hj5n7cqpaf0mt2d0xx85tgz8"))
+	assert.NoError(t, utils.ValidateID("cus_1-A"))
+	for _, id := range []string{"", "a/b", "a?b", "a#b", "..", "a b", strings.Repeat("a", 129)} {
+		assert.Error(t, utils.ValidateID(id), id)
+	}
+}
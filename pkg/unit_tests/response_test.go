@@ -0,0 +1,50 @@
+package unit_tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req_123")
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		if r.URL.Path == "/test/api/v2/customers/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		w.Write([]byte(`{"id":"cus_1","name":"Alice"}`))
+	}))
+	defer server.Close()
+
+	client, _ := chargily.NewClient("test_api_key", "test", chargily.WithMiddleware(redirectTo(server)))
+
+	var res chargily.Response
+	customer, err := client.WithResponse(&res).Customers.Get("cus_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice", customer.Name)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "req_123", res.RequestID)
+	assert.Equal(t, "59", res.Header.Get("X-RateLimit-Remaining"))
+	assert.JSONEq(t, `{"id":"cus_1","name":"Alice"}`, string(res.Body))
+	assert.Equal(t, 1, res.Attempts)
+	assert.Positive(t, res.Latency)
+
+	// failed calls are captured too
+	_, err = client.WithResponse(&res).Customers.Get("missing")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.JSONEq(t, `{"message":"Not found"}`, string(res.Body))
+
+	// with a request option
+	var created chargily.Response
+	_, err = client.Customers.Create(&models.CreateCustomerParams{Name: "Alice"}, chargily.CaptureResponse(&created))
+	assert.NoError(t, err)
+	assert.Equal(t, "req_123", created.RequestID)
+}
@@ -0,0 +1,53 @@
+package unit_tests
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampUnmarshal(t *testing.T) {
+	expected := time.Date(2023, 12, 21, 7, 42, 47, 0, time.UTC)
+
+	for _, raw := range []string{`1703144567`, `"1703144567"`, `"2023-12-21T08:42:47+01:00"`} {
+		var ts models.Timestamp
+		assert.NoError(t, json.Unmarshal([]byte(raw), &ts), raw)
+		assert.True(t, expected.Equal(ts.Time), raw)
+		assert.Equal(t, time.UTC, ts.Location(), raw)
+	}
+
+	for _, raw := range []string{`null`, `""`} {
+		ts := models.UnixTimestamp(1)
+		assert.NoError(t, json.Unmarshal([]byte(raw), &ts), raw)
+		assert.True(t, ts.IsZero(), raw)
+	}
+
+	var ts models.Timestamp
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &ts))
+	assert.Error(t, json.Unmarshal([]byte(`true`), &ts))
+}
+
+func TestTimestampRoundTrip(t *testing.T) {
+	raw := `{"id":"cus_1","created_at":1703144567,"updated_at":null}`
+
+	var customer models.Customer
+	assert.NoError(t, json.Unmarshal([]byte(raw), &customer))
+	assert.Equal(t, int64(1703144567), customer.CreatedAt.Unix())
+	assert.True(t, customer.UpdatedAt.IsZero())
+
+	encoded, err := json.Marshal(customer)
+	assert.NoError(t, err)
+
+	var fields map[string]any
+	assert.NoError(t, json.Unmarshal(encoded, &fields))
+	assert.Equal(t, float64(1703144567), fields["created_at"])
+	assert.Nil(t, fields["updated_at"])
+
+	// the Unix epoch is not the zero timestamp
+	encoded, err = json.Marshal(models.UnixTimestamp(0))
+	assert.NoError(t, err)
+	assert.Equal(t, "0", string(encoded))
+}
@@ -0,0 +1,267 @@
+package unit_tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+	"github.com/Chargily/chargily-pay-go/pkg/webhookqueue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessorRetriesAndDeadLetters(t *testing.T) {
+	client, _ := chargily.NewClient("test_key", "test")
+
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	handled := make(chan string, 10)
+	deadLetters := &webhookqueue.MemoryDeadLetters{}
+	processor := webhookqueue.New(webhookqueue.NewMemoryQueue(10),
+		func(ctx context.Context, event models.WebhookEvent) error {
+			mu.Lock()
+			attempts[event.ID]++
+			attempt := attempts[event.ID]
+			mu.Unlock()
+
+			switch {
+			case event.ID == "evt_flaky" && attempt < 3:
+				return errors.New("erp unavailable")
+			case event.ID == "evt_broken":
+				panic("bad event")
+			}
+			handled <- event.ID
+			return nil
+		},
+		webhookqueue.Workers(2),
+		webhookqueue.Retries(3, time.Millisecond),
+		webhookqueue.DeadLetters(deadLetters))
+	processor.Start()
+
+	server := httptest.NewServer(processor.Handler(client.Webhook))
+	defer server.Close()
+	for _, id := range []string{"evt_ok", "evt_flaky", "evt_broken"} {
+		payload := []byte(`{"id":"` + id + `","type":"checkout.paid","livemode":"false","data":{"id":"chk_1"}}`)
+		req, _ := http.NewRequest("POST", server.URL, bytes.NewReader(payload))
+		req.Header.Set("signature", signPayload(payload, "test_key"))
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+
+	assert.NoError(t, processor.Shutdown(context.Background()))
+	close(handled)
+	var ids []string
+	for id := range handled {
+		ids = append(ids, id)
+	}
+	assert.ElementsMatch(t, []string{"evt_ok", "evt_flaky"}, ids)
+	assert.Equal(t, 3, attempts["evt_flaky"])
+	assert.Equal(t, 3, attempts["evt_broken"])
+
+	letters := deadLetters.Letters()
+	assert.Len(t, letters, 1)
+	assert.Equal(t, "evt_broken", letters[0].Job.Event.ID)
+	assert.Equal(t, 3, letters[0].Job.Attempts)
+	assert.Equal(t, "panic: bad event", letters[0].Error)
+
+	// no event is accepted after the shutdown
+	assert.ErrorIs(t, processor.Enqueue(context.Background(), models.WebhookEvent{ID: "evt_late"}), webhookqueue.ErrClosed)
+}
+
+func TestMemoryQueueFull(t *testing.T) {
+	client, _ := chargily.NewClient("test_key", "test")
+	processor := webhookqueue.New(webhookqueue.NewMemoryQueue(1),
+		func(ctx context.Context, event models.WebhookEvent) error { return nil })
+
+	// the workers are not started, the second event doesn't fit and Chargily sends it again later
+	handler := processor.Handler(client.Webhook)
+	post := func(id string) int {
+		payload := []byte(`{"id":"` + id + `","livemode":"false"}`)
+		req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(payload))
+		req.Header.Set("signature", signPayload(payload, "test_key"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusOK, post("evt_1"))
+	assert.Equal(t, http.StatusInternalServerError, post("evt_2"))
+}
+
+// flakyQueue fails to push the first jobs
+type flakyQueue struct {
+	webhookqueue.Queue
+	failures int
+}
+
+func (q *flakyQueue) Push(ctx context.Context, job webhookqueue.Job) error {
+	if q.failures > 0 {
+		q.failures--
+		return errors.New("disk full")
+	}
+	return q.Queue.Push(ctx, job)
+}
+
+func TestProcessorRetriedEnqueue(t *testing.T) {
+	client, _ := chargily.NewClient("test_key", "test",
+		chargily.WithReplayProtection(time.Minute, chargily.NewMemoryEventStore(time.Minute)))
+
+	handled := make(chan string, 10)
+	processor := webhookqueue.New(&flakyQueue{Queue: webhookqueue.NewMemoryQueue(10), failures: 1},
+		func(ctx context.Context, event models.WebhookEvent) error {
+			handled <- event.ID
+			return nil
+		})
+	processor.Start()
+
+	handler := processor.Handler(client.Webhook)
+	post := func(payload []byte) int {
+		req := httptest.NewRequest("POST", "/webhook", bytes.NewReader(payload))
+		req.Header.Set("signature", signPayload(payload, "test_key"))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// the event that couldn't be queued isn't taken for a replay when Chargily sends it again
+	payload := eventPayload("evt_1", time.Now())
+	assert.Equal(t, http.StatusInternalServerError, post(payload))
+	assert.Equal(t, http.StatusOK, post(payload))
+	// the event queued once is a replay
+	assert.Equal(t, http.StatusOK, post(payload))
+
+	assert.NoError(t, processor.Shutdown(context.Background()))
+	close(handled)
+	var ids []string
+	for id := range handled {
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []string{"evt_1"}, ids)
+}
+
+func TestFileQueueRedelivers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.journal")
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	queue, err := webhookqueue.OpenFileQueue(path)
+	assert.NoError(t, err)
+	processor := webhookqueue.New(queue, func(ctx context.Context, event models.WebhookEvent) error { return nil })
+	for _, id := range []string{"evt_1", "evt_2"} {
+		event := models.WebhookEvent{ID: id, LiveMode: true, CreatedAt: models.NewTimestamp(createdAt),
+			Type: "checkout.paid"}
+		event.Data.Metadata = models.Metadata{"order_id": "42"}
+		assert.NoError(t, processor.Enqueue(context.Background(), event))
+	}
+
+	// the process stops before the workers run: the events are delivered to the next one
+	queue, err = webhookqueue.OpenFileQueue(path)
+	assert.NoError(t, err)
+	var received []models.WebhookEvent
+	processor = webhookqueue.New(queue, func(ctx context.Context, event models.WebhookEvent) error {
+		received = append(received, event)
+		return nil
+	}, webhookqueue.Workers(1))
+	processor.Start()
+	assert.NoError(t, processor.Shutdown(context.Background()))
+
+	assert.Len(t, received, 2)
+	assert.Equal(t, "evt_1", received[0].ID)
+	assert.True(t, received[0].LiveMode)
+	assert.True(t, received[0].CreatedAt.Equal(createdAt))
+	assert.Equal(t, "42", received[0].Data.Metadata.Get("order_id"))
+
+	// once done, they are not delivered again
+	queue, err = webhookqueue.OpenFileQueue(path)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = queue.Pop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NoError(t, queue.Close())
+}
+
+func TestProcessorShutdownDeadline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.journal")
+	queue, err := webhookqueue.OpenFileQueue(path)
+	assert.NoError(t, err)
+
+	var started int32
+	processor := webhookqueue.New(queue, func(ctx context.Context, event models.WebhookEvent) error {
+		atomic.AddInt32(&started, 1)
+		<-ctx.Done()
+		return ctx.Err()
+	}, webhookqueue.Workers(1))
+	processor.Start()
+	assert.NoError(t, processor.Enqueue(context.Background(), models.WebhookEvent{ID: "evt_slow"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, processor.Shutdown(ctx), context.DeadlineExceeded)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&started))
+
+	// the interrupted event stays in the journal
+	queue, err = webhookqueue.OpenFileQueue(path)
+	assert.NoError(t, err)
+	job, err := queue.Pop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "evt_slow", job.Event.ID)
+}
+
+// openFiles counts the files of the process open at path
+func openFiles(t *testing.T, path string) int {
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skip("the open files are listed in /proc")
+	}
+	count := 0
+	for _, fd := range fds {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", fd.Name())); err == nil && target == path {
+			count++
+		}
+	}
+	return count
+}
+
+func TestFileQueueShutdownDeadline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.journal")
+	queue, err := webhookqueue.OpenFileQueue(path)
+	assert.NoError(t, err)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	processor := webhookqueue.New(queue, func(ctx context.Context, event models.WebhookEvent) error {
+		close(started)
+		// a handler ignoring its context
+		<-release
+		return nil
+	}, webhookqueue.Workers(1))
+	processor.Start()
+	assert.NoError(t, processor.Enqueue(context.Background(), models.WebhookEvent{ID: "evt_1"}))
+	<-started
+	assert.Equal(t, 1, openFiles(t, path))
+
+	// the journal is closed although the job is still in flight
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, processor.Shutdown(ctx), context.DeadlineExceeded)
+	assert.Equal(t, 0, openFiles(t, path))
+	close(release)
+
+	// the interrupted job is delivered to the next process
+	queue, err = webhookqueue.OpenFileQueue(path)
+	assert.NoError(t, err)
+	assert.NoError(t, queue.Close())
+	job, err := queue.Pop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "evt_1", job.Event.ID)
+	assert.NoError(t, queue.Done(job))
+}
@@ -0,0 +1,94 @@
+package webhookqueue
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DeadLetter is a job that failed every attempt, kept to be inspected and replayed by hand.
+type DeadLetter struct {
+	Job      Job       `json:"job"`
+	Error    string    `json:"error"` // The error of the last attempt.
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// DeadLetterStore keeps the dead letters, see DeadLetters.
+type DeadLetterStore interface {
+	Put(ctx context.Context, letter DeadLetter) error
+}
+
+// MemoryDeadLetters keeps the dead letters in memory, e.g. for tests.
+type MemoryDeadLetters struct {
+	mu      sync.Mutex
+	letters []DeadLetter
+}
+
+func (s *MemoryDeadLetters) Put(ctx context.Context, letter DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.letters = append(s.letters, letter)
+	return nil
+}
+
+// Letters returns the dead letters, oldest first.
+func (s *MemoryDeadLetters) Letters() []DeadLetter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]DeadLetter(nil), s.letters...)
+}
+
+// FileDeadLetters appends the dead letters to a JSON Lines file, read them with ReadDeadLetters.
+type FileDeadLetters struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileDeadLetters creates the store appending to the file at path, created when the first letter is put.
+func NewFileDeadLetters(path string) *FileDeadLetters {
+	return &FileDeadLetters{path: path}
+}
+
+func (s *FileDeadLetters) Put(ctx context.Context, letter DeadLetter) error {
+	line, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ReadDeadLetters reads the dead letters written by FileDeadLetters.
+func ReadDeadLetters(path string) ([]DeadLetter, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var letters []DeadLetter
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var letter DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &letter); err != nil {
+			return nil, fmt.Errorf("webhookqueue: dead letters %s line %d: %v", path, line, err)
+		}
+		letters = append(letters, letter)
+	}
+	return letters, scanner.Err()
+}
@@ -0,0 +1,213 @@
+package webhookqueue
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// journalRecord is a line of the journal of a FileQueue: a pushed job or the ID of a finished one
+type journalRecord struct {
+	Push *Job   `json:"push,omitempty"`
+	Done string `json:"done,omitempty"`
+}
+
+// FileQueue is a Queue whose jobs are written to a journal file before the webhook request is
+// acknowledged. The jobs not finished when the process stops, even abruptly, are delivered again
+// when the queue is opened by the next process.
+type FileQueue struct {
+	jobs *pending
+
+	mu   sync.Mutex // guards the file
+	file *os.File
+}
+
+var _ Aborter = (*FileQueue)(nil)
+
+// OpenFileQueue opens the journal at path, creating it if needed, and queues its unfinished jobs.
+// The journal is compacted to these jobs. Only one process may open a journal at a time.
+func OpenFileQueue(path string) (*FileQueue, error) {
+	unfinished, err := readJournal(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeJournal(path, unfinished); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	q := &FileQueue{jobs: newPending(), file: file}
+	q.jobs.jobs = unfinished
+	return q, nil
+}
+
+func (q *FileQueue) Push(ctx context.Context, job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.file == nil || q.jobs.isClosed() {
+		return ErrClosed
+	}
+
+	// the job is on disk before the request is acknowledged
+	if err := q.append(journalRecord{Push: &job}); err != nil {
+		return err
+	}
+	if err := q.file.Sync(); err != nil {
+		return fmt.Errorf("webhookqueue: journal: %w", err)
+	}
+	return q.jobs.push(job, 0)
+}
+
+func (q *FileQueue) Pop(ctx context.Context) (Job, error) {
+	return q.jobs.pop(ctx)
+}
+
+func (q *FileQueue) Done(job Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs.done()
+	if q.file == nil {
+		return ErrClosed
+	}
+
+	if err := q.append(journalRecord{Done: job.ID}); err != nil {
+		return err
+	}
+	return q.release()
+}
+
+// Close stops accepting jobs, the journal file is closed once the queued jobs are done.
+func (q *FileQueue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs.close()
+	return q.release()
+}
+
+// Abort stops accepting jobs and closes the journal file right away, the jobs not done yet are
+// delivered again when the journal is opened by the next process.
+func (q *FileQueue) Abort() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs.close()
+	if q.file == nil {
+		return nil
+	}
+
+	err := q.file.Close()
+	q.file = nil
+	return err
+}
+
+// release closes the journal file once the queue is closed and every job is done, q.mu is held
+func (q *FileQueue) release() error {
+	if q.file == nil || !q.jobs.drained() {
+		return nil
+	}
+
+	err := q.file.Close()
+	q.file = nil
+	return err
+}
+
+// append writes a record at the end of the journal, q.mu is held
+func (q *FileQueue) append(record journalRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err := q.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("webhookqueue: journal: %w", err)
+	}
+	return nil
+}
+
+// readJournal returns the jobs of a journal not done yet, in the order they were pushed
+func readJournal(path string) ([]Job, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var jobs []Job
+	done := make(map[string]bool)
+	reader := bufio.NewReader(file)
+	for line := 1; ; line++ {
+		raw, err := reader.ReadBytes('\n')
+		if len(raw) > 0 && raw[len(raw)-1] == '\n' {
+			var record journalRecord
+			if err := json.Unmarshal(raw, &record); err != nil {
+				return nil, fmt.Errorf("webhookqueue: journal %s line %d: %v", path, line, err)
+			}
+			if record.Push != nil {
+				jobs = append(jobs, *record.Push)
+			}
+			if record.Done != "" {
+				done[record.Done] = true
+			}
+		}
+		// an incomplete last line was being written when the process stopped, its job wasn't acknowledged
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unfinished := jobs[:0]
+	for _, job := range jobs {
+		if !done[job.ID] {
+			unfinished = append(unfinished, job)
+		}
+	}
+	return unfinished, nil
+}
+
+// writeJournal replaces the journal with the pushes of the given jobs
+func writeJournal(path string, jobs []Job) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	for i := range jobs {
+		line, err := json.Marshal(journalRecord{Push: &jobs[i]})
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		w.Write(append(line, '\n'))
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
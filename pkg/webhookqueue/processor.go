@@ -0,0 +1,270 @@
+// Package webhookqueue processes the Chargily webhook events in the background: the webhook handler
+// verifies an event, persists it in a queue and acknowledges the request right away, then a
+// bounded pool of workers runs the slow handlers (emails, ERP sync...) with retries, and keeps
+// the events failing every attempt as dead letters.
+package webhookqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/chargily"
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// maxBackoff is the longest wait between two attempts of a job
+const maxBackoff = time.Minute
+
+// Handler processes an event, the event is attempted again when it returns an error or panics.
+type Handler func(ctx context.Context, event models.WebhookEvent) error
+
+// Processor receives the webhook events into a queue and processes them with a pool of workers.
+type Processor struct {
+	queue       Queue
+	handler     Handler
+	workers     int
+	attempts    int
+	backoff     time.Duration
+	deadLetters DeadLetterStore
+	journal     chargily.EventJournal
+	logger      *slog.Logger
+
+	start  sync.Once
+	ctx    context.Context // canceled when the shutdown deadline is reached
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Option customizes a Processor.
+type Option func(*Processor)
+
+// Workers sets the number of events processed concurrently (defaults to 4).
+func Workers(n int) Option {
+	return func(p *Processor) {
+		if n > 0 {
+			p.workers = n
+		}
+	}
+}
+
+// Retries sets how many times an event is attempted (defaults to 5) and the wait after the first
+// failed attempt (defaults to 1 second), doubled after every other failure up to a minute.
+func Retries(attempts int, backoff time.Duration) Option {
+	return func(p *Processor) {
+		if attempts > 0 {
+			p.attempts = attempts
+		}
+		if backoff > 0 {
+			p.backoff = backoff
+		}
+	}
+}
+
+// DeadLetters keeps the events failing every attempt in the store, they are only logged otherwise.
+func DeadLetters(store DeadLetterStore) Option {
+	return func(p *Processor) { p.deadLetters = store }
+}
+
+// Journal records the outcome of the processing in the event journal the webhook requests are
+// recorded in (see chargily.WithEventJournal): handled, or failed once every attempt failed.
+func Journal(journal chargily.EventJournal) Option {
+	return func(p *Processor) { p.journal = journal }
+}
+
+// Logger logs the failed attempts and the dead letters to the logger instead of the default slog logger.
+func Logger(logger *slog.Logger) Option {
+	return func(p *Processor) { p.logger = logger }
+}
+
+// New creates a processor pushing the received events to the queue and passing them to the handler.
+// Start the workers with Start, and stop them with Shutdown.
+func New(queue Queue, handler Handler, opts ...Option) *Processor {
+	p := &Processor{
+		queue:    queue,
+		handler:  handler,
+		workers:  4,
+		attempts: 5,
+		backoff:  time.Second,
+		logger:   slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	return p
+}
+
+// Handler returns the http.Handler verifying the webhook requests with the webhook of a client,
+// and acknowledging them as soon as their event is in the queue.
+func (p *Processor) Handler(webhook chargily.WebhookAPI) http.Handler {
+	return webhook.EnqueueHandler(p.Enqueue)
+}
+
+// Enqueue pushes an event to the queue, it fails with ErrClosed once the processor is shut down.
+func (p *Processor) Enqueue(ctx context.Context, event models.WebhookEvent) error {
+	return p.queue.Push(ctx, newJob(event))
+}
+
+// Start starts the workers, it does nothing when they are already started.
+func (p *Processor) Start() {
+	p.start.Do(func() {
+		for i := 0; i < p.workers; i++ {
+			p.wg.Add(1)
+			go p.work()
+		}
+	})
+}
+
+// Shutdown stops accepting events and waits for the workers to process the queued ones. When ctx
+// is done first the workers are stopped and ctx.Err() is returned: the events being processed are
+// interrupted, and the unfinished ones stay in the queue if it is persistent (see FileQueue). The
+// queue is aborted if it implements Aborter, e.g. the journal file of a FileQueue is closed.
+func (p *Processor) Shutdown(ctx context.Context) error {
+	err := p.queue.Close()
+
+	drained := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return err
+	case <-ctx.Done():
+		p.cancel()
+		if aborter, ok := p.queue.(Aborter); ok {
+			if err := aborter.Abort(); err != nil {
+				return errors.Join(ctx.Err(), err)
+			}
+		}
+		return ctx.Err()
+	}
+}
+
+// work processes the jobs of the queue until it is closed and empty
+func (p *Processor) work() {
+	defer p.wg.Done()
+	for {
+		job, err := p.queue.Pop(p.ctx)
+		if err != nil {
+			return
+		}
+		p.process(job)
+	}
+}
+
+// process attempts a job until it succeeds or fails every attempt
+func (p *Processor) process(job Job) {
+	for {
+		job.Attempts++
+		err := p.handle(job)
+		if err == nil {
+			p.recordOutcome(job, chargily.EventHandled, nil)
+			p.done(job)
+			return
+		}
+
+		if job.Attempts >= p.attempts {
+			p.recordOutcome(job, chargily.EventFailed, err)
+			p.deadLetter(job, err)
+			p.done(job)
+			return
+		}
+
+		delay := p.delay(job.Attempts)
+		p.logger.Warn("webhookqueue: event failed, retrying",
+			slog.String("event_id", job.Event.ID),
+			slog.String("event_type", job.Event.Type),
+			slog.Int("attempt", job.Attempts),
+			slog.Duration("retry_in", delay),
+			slog.String("error", err.Error()),
+		)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-p.ctx.Done():
+			// shut down, the job is not done
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// handle calls the handler, a panic is turned into an error
+func (p *Processor) handle(job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return p.handler(p.ctx, job.Event)
+}
+
+// delay returns the wait after the given failed attempt
+func (p *Processor) delay(attempt int) time.Duration {
+	delay := p.backoff
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// done marks the job as finished in the queue
+func (p *Processor) done(job Job) {
+	if err := p.queue.Done(job); err != nil {
+		p.logger.Error("webhookqueue: failed to mark the event as done",
+			slog.String("event_id", job.Event.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// recordOutcome updates the record of the event in the journal, if any
+func (p *Processor) recordOutcome(job Job, outcome chargily.EventOutcome, cause error) {
+	if p.journal == nil {
+		return
+	}
+
+	record, err := p.journal.Get(p.ctx, job.Event.ID)
+	if err == nil {
+		record.Outcome, record.UpdatedAt, record.Error = outcome, time.Now(), ""
+		if cause != nil {
+			record.Error = cause.Error()
+		}
+		err = p.journal.Record(p.ctx, *record)
+	}
+	if err != nil && !errors.Is(err, chargily.ErrEventNotFound) {
+		p.logger.Warn("webhookqueue: failed to record the event outcome",
+			slog.String("event_id", job.Event.ID),
+			slog.String("outcome", string(outcome)),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// deadLetter keeps a job that failed every attempt
+func (p *Processor) deadLetter(job Job, err error) {
+	attrs := []any{
+		slog.String("event_id", job.Event.ID),
+		slog.String("event_type", job.Event.Type),
+		slog.Int("attempts", job.Attempts),
+		slog.String("error", err.Error()),
+	}
+	if p.deadLetters == nil {
+		p.logger.Error("webhookqueue: event failed every attempt", attrs...)
+		return
+	}
+
+	letter := DeadLetter{Job: job, Error: err.Error(), FailedAt: time.Now()}
+	if putErr := p.deadLetters.Put(p.ctx, letter); putErr != nil {
+		p.logger.Error("webhookqueue: failed to store the dead letter",
+			append(attrs, slog.String("store_error", putErr.Error()))...)
+	}
+}
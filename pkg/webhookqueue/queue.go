@@ -0,0 +1,181 @@
+package webhookqueue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Chargily/chargily-pay-go/pkg/models"
+)
+
+// ErrClosed is returned by Pop once the queue is closed and empty, and by Push once it is closed.
+var ErrClosed = errors.New("webhookqueue: queue closed")
+
+// ErrFull is returned by Push when a bounded queue has no room left, the webhook request fails
+// and Chargily sends the event again later.
+var ErrFull = errors.New("webhookqueue: queue full")
+
+// Job is an event waiting to be processed.
+type Job struct {
+	ID         string              `json:"id"`
+	Event      models.WebhookEvent `json:"event"`
+	Attempts   int                 `json:"attempts"` // Attempts made so far, by the current process.
+	EnqueuedAt time.Time           `json:"enqueued_at"`
+}
+
+// newJob creates the job of an event, with a random ID
+func newJob(event models.WebhookEvent) Job {
+	id := make([]byte, 12)
+	rand.Read(id)
+	return Job{ID: hex.EncodeToString(id), Event: event, EnqueuedAt: time.Now()}
+}
+
+// Queue holds the events between their reception and their processing.
+type Queue interface {
+	// Push stores the job, the webhook request is acknowledged once it returns.
+	Push(ctx context.Context, job Job) error
+	// Pop waits for the next job, it returns ErrClosed once the queue is closed and empty.
+	Pop(ctx context.Context) (Job, error)
+	// Done records that the job is finished, handled or dead lettered, so it isn't delivered again.
+	Done(job Job) error
+	// Close stops accepting jobs, the queued ones can still be popped.
+	Close() error
+}
+
+// Aborter is implemented by the queues holding resources until their popped jobs are done, e.g. a
+// file. Shutdown calls Abort when its deadline is reached before the jobs are done: the queue
+// releases its resources, and the unfinished jobs are delivered again if it is persistent.
+type Aborter interface {
+	Abort() error
+}
+
+// pending is the list of the jobs waiting for a worker, shared by the queues
+type pending struct {
+	mu       sync.Mutex
+	jobs     []Job
+	inFlight int           // popped jobs not done yet
+	ready    chan struct{} // signaled when a job is added
+	closed   chan struct{} // closed by close
+	isDone   bool
+}
+
+func newPending() *pending {
+	return &pending{ready: make(chan struct{}, 1), closed: make(chan struct{})}
+}
+
+// push adds a job, unless the list is closed or already holds max jobs (0 for no limit)
+func (p *pending) push(job Job, max int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.isDone {
+		return ErrClosed
+	}
+	if max > 0 && len(p.jobs) >= max {
+		return ErrFull
+	}
+	p.jobs = append(p.jobs, job)
+	p.signal()
+	return nil
+}
+
+// pop waits for the first job
+func (p *pending) pop(ctx context.Context) (Job, error) {
+	for {
+		p.mu.Lock()
+		if len(p.jobs) > 0 {
+			job := p.jobs[0]
+			p.jobs = p.jobs[1:]
+			p.inFlight++
+			// wake another worker for the remaining jobs
+			if len(p.jobs) > 0 {
+				p.signal()
+			}
+			p.mu.Unlock()
+			return job, nil
+		}
+		isDone := p.isDone
+		p.mu.Unlock()
+		if isDone {
+			return Job{}, ErrClosed
+		}
+
+		select {
+		case <-p.ready:
+		case <-p.closed:
+		case <-ctx.Done():
+			return Job{}, ctx.Err()
+		}
+	}
+}
+
+// signal wakes a waiting worker, p.mu is held
+func (p *pending) signal() {
+	select {
+	case p.ready <- struct{}{}:
+	default:
+	}
+}
+
+// done records that a popped job is finished
+func (p *pending) done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight--
+}
+
+// drained reports whether the list is closed and every job is done
+func (p *pending) drained() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.isDone && len(p.jobs) == 0 && p.inFlight == 0
+}
+
+// isClosed reports whether close was called
+func (p *pending) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.isDone
+}
+
+// close stops accepting jobs and wakes the waiting workers
+func (p *pending) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.isDone {
+		p.isDone = true
+		close(p.closed)
+	}
+}
+
+// MemoryQueue is a Queue held in memory. The events still queued are lost when the process
+// stops before they are processed, use a FileQueue to keep them.
+type MemoryQueue struct {
+	jobs     *pending
+	capacity int
+}
+
+// NewMemoryQueue creates a queue holding up to capacity jobs, Push returns ErrFull beyond.
+func NewMemoryQueue(capacity int) *MemoryQueue {
+	return &MemoryQueue{jobs: newPending(), capacity: capacity}
+}
+
+func (q *MemoryQueue) Push(ctx context.Context, job Job) error {
+	return q.jobs.push(job, q.capacity)
+}
+
+func (q *MemoryQueue) Pop(ctx context.Context) (Job, error) {
+	return q.jobs.pop(ctx)
+}
+
+func (q *MemoryQueue) Done(job Job) error {
+	q.jobs.done()
+	return nil
+}
+
+func (q *MemoryQueue) Close() error {
+	q.jobs.close()
+	return nil
+}